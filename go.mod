@@ -8,6 +8,7 @@ require (
 	codeberg.org/tslocum/cbind v0.1.6
 	github.com/atotto/clipboard v0.1.4
 	github.com/creack/pty v1.1.24
+	github.com/dlclark/regexp2 v1.7.0
 	github.com/fsnotify/fsnotify v1.9.0
 	github.com/gdamore/tcell/v2 v2.9.0
 	github.com/google/shlex v0.0.0-20191202100458-e7afc7fbc510
@@ -26,6 +27,7 @@ require (
 	golang.org/x/sync v0.17.0
 	golang.org/x/sys v0.37.0
 	golang.org/x/term v0.36.0
+	golang.org/x/text v0.30.0
 )
 
 require (
@@ -41,6 +43,5 @@ require (
 	github.com/spf13/cast v1.10.0 // indirect
 	github.com/subosito/gotenv v1.6.0 // indirect
 	go.yaml.in/yaml/v3 v3.0.4 // indirect
-	golang.org/x/text v0.30.0 // indirect
 	gopkg.in/check.v1 v1.0.0-20190902080502-41f04d3bba15 // indirect
 )
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bufio"
 	"bytes"
 	"errors"
 	"fmt"
@@ -38,6 +39,12 @@ var (
 	// non match filter pattern.
 	nonMatchFilter string
 
+	// filterExpr is filter expression.
+	filterExpr string
+
+	// multiColorFile is the file of words to color.
+	multiColorFile string
+
 	// ver is version information.
 	ver bool
 	// helpKey is key bind information.
@@ -48,6 +55,8 @@ var (
 	completion string
 	// execCommand targets the output of executing the command.
 	execCommand bool
+	// exportTable is the format to export the table view without displaying the screen.
+	exportTable string
 
 	// forceScreen is display screen even when redirecting output.
 	forceScreen bool
@@ -101,6 +110,14 @@ It supports various compressed files(gzip, bzip2, zstd, lz4, and xz).
 		oviewer.OverLineStyle = oviewer.ToTcellStyle(config.StyleOverLine)
 		oviewer.MemoryLimit = config.MemoryLimit
 		oviewer.MemoryLimitFile = config.MemoryLimitFile
+		if multiColorFile != "" {
+			if err := addMultiColorFile(multiColorFile); err != nil {
+				return err
+			}
+		}
+		if exportTable != "" {
+			return oviewer.ExportTable(os.Stdout, config, exportTable, argsToFiles(args)...)
+		}
 		SetRedirect()
 		// Do not display the screen if redirected (unless forceScreen is specified).
 		if oviewer.STDOUTPIPE != nil && !forceScreen {
@@ -188,8 +205,11 @@ func RunOviewer(args []string) error {
 	if nonMatchFilter != "" {
 		ov.Filter(nonMatchFilter, true)
 	}
+	if filterExpr != "" {
+		ov.FilterExpr(filterExpr)
+	}
 
-	if ov.Config.QuitSmall && (filter != "" || nonMatchFilter != "") {
+	if ov.Config.QuitSmall && (filter != "" || nonMatchFilter != "" || filterExpr != "") {
 		ov.Config.QuitSmallFilter = true
 	}
 	// Run oviewer.
@@ -201,6 +221,34 @@ func RunOviewer(args []string) error {
 	return nil
 }
 
+// addMultiColorFile adds the words in the file to the multi-color words.
+// Empty lines and lines starting with "#" are ignored.
+func addMultiColorFile(fileName string) error {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var words []string
+	if config.General.MultiColorWords != nil {
+		words = append(words, *config.General.MultiColorWords...)
+	}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		word := strings.TrimRight(scanner.Text(), "\r")
+		if word == "" || strings.HasPrefix(word, "#") {
+			continue
+		}
+		words = append(words, word)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("%s: %w", fileName, err)
+	}
+	config.General.SetMultiColorWords(words)
+	return nil
+}
+
 // ExecCommand targets the output of command execution (stdout/stderr).
 func ExecCommand(args []string) error {
 	if len(args) == 0 {
@@ -387,6 +435,11 @@ func init() {
 
 	rootCmd.PersistentFlags().BoolVarP(&forceScreen, "force-screen", "", false, "display screen even when redirecting output")
 
+	rootCmd.PersistentFlags().StringVarP(&exportTable, "export-table", "", "", "export the table view to standard output [csv|tsv|markdown|json]")
+	_ = rootCmd.RegisterFlagCompletionFunc("export-table", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return []string{"csv", "tsv", "markdown", "json"}, cobra.ShellCompDirectiveNoFileComp
+	})
+
 	rootCmd.PersistentFlags().StringVarP(&completion, "completion", "", "", "generate completion script [bash|zsh|fish|powershell]")
 	_ = rootCmd.RegisterFlagCompletionFunc("completion", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
 		return []string{"bash", "zsh", "fish", "powershell"}, cobra.ShellCompDirectiveNoFileComp
@@ -395,13 +448,14 @@ func init() {
 	rootCmd.PersistentFlags().StringVarP(&pattern, "pattern", "", "", "search pattern")
 	rootCmd.PersistentFlags().StringVarP(&filter, "filter", "", "", "filter search pattern")
 	rootCmd.PersistentFlags().StringVarP(&nonMatchFilter, "non-match-filter", "", "", "filter non match search pattern")
+	rootCmd.PersistentFlags().StringVarP(&filterExpr, "filter-expr", "", "", "filter by expression comparing columns")
 	rootCmd.PersistentFlags().BoolVarP(&oviewer.SkipExtract, "skip-extract", "", false, "skip extracting compressed files")
 
 	// Config.General
-	rootCmd.PersistentFlags().StringP("converter", "", "es", "converter [es|raw|align]")
+	rootCmd.PersistentFlags().StringP("converter", "", "es", "converter [es|raw|align|logfmt|parser]")
 	_ = viper.BindPFlag("general.Converter", rootCmd.PersistentFlags().Lookup("converter"))
 	_ = rootCmd.RegisterFlagCompletionFunc("converter", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
-		return []string{"es\tEscape Sequence", "raw\tRaw output of escape sequences", "align\tAlign Column Widths"}, cobra.ShellCompDirectiveNoFileComp
+		return []string{"es\tEscape Sequence", "raw\tRaw output of escape sequences", "align\tAlign Column Widths", "logfmt\tLogfmt key=value columns", "parser\tNamed groups of the parser as columns"}, cobra.ShellCompDirectiveNoFileComp
 	})
 
 	rootCmd.PersistentFlags().BoolP("align", "l", false, "align the output columns for better readability")
@@ -457,6 +511,9 @@ func init() {
 	rootCmd.PersistentFlags().BoolP("wrap", "w", true, "wrap mode")
 	_ = viper.BindPFlag("general.WrapMode", rootCmd.PersistentFlags().Lookup("wrap"))
 
+	rootCmd.PersistentFlags().BoolP("table-wrap", "", false, "wrap long cells within their column width (align mode)")
+	_ = viper.BindPFlag("general.TableWrap", rootCmd.PersistentFlags().Lookup("table-wrap"))
+
 	rootCmd.PersistentFlags().BoolP("plain", "p", false, "disable original decoration")
 	_ = viper.BindPFlag("general.PlainMode", rootCmd.PersistentFlags().Lookup("plain"))
 
@@ -495,6 +552,25 @@ func init() {
 
 	rootCmd.PersistentFlags().StringSliceP("multi-color", "M", nil, "comma separated words(regexp) to color .e.g. \"ERROR,WARNING\"")
 	_ = viper.BindPFlag("general.MultiColorWords", rootCmd.PersistentFlags().Lookup("multi-color"))
+	rootCmd.PersistentFlags().StringVarP(&multiColorFile, "multi-color-file", "", "", "file of words(regexp) to color, one per line")
+
+	rootCmd.PersistentFlags().StringP("parser", "", "", "parser name or regular expression with named groups")
+	_ = viper.BindPFlag("general.Parser", rootCmd.PersistentFlags().Lookup("parser"))
+
+	rootCmd.PersistentFlags().StringP("regexp-engine", "", "", "regular expression engine [re2|pcre]")
+	_ = viper.BindPFlag("general.RegexpEngine", rootCmd.PersistentFlags().Lookup("regexp-engine"))
+	_ = rootCmd.RegisterFlagCompletionFunc("regexp-engine", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return []string{"re2\tGo regexp (default)", "pcre\tbacktracking with lookaround and backreferences"}, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	rootCmd.PersistentFlags().StringP("time-format", "", "", "timestamp format for goto time (layout or /regexp/)")
+	_ = viper.BindPFlag("general.TimeFormat", rootCmd.PersistentFlags().Lookup("time-format"))
+
+	rootCmd.PersistentFlags().StringP("search-column", "", "", "limit the search to the column `[int|name]`")
+	_ = viper.BindPFlag("general.SearchColumn", rootCmd.PersistentFlags().Lookup("search-column"))
+
+	rootCmd.PersistentFlags().StringSliceP("logfmt-hide-keys", "", nil, "comma separated keys to hide in the logfmt converter")
+	_ = viper.BindPFlag("general.LogfmtHideKeys", rootCmd.PersistentFlags().Lookup("logfmt-hide-keys"))
 
 	rootCmd.PersistentFlags().StringP("jump-target", "j", "", "jump target `[int|int%|.int|'section']`")
 	_ = viper.BindPFlag("general.JumpTarget", rootCmd.PersistentFlags().Lookup("jump-target"))
@@ -547,6 +623,39 @@ func init() {
 	rootCmd.PersistentFlags().BoolP("incsearch", "", true, "incremental search")
 	_ = viper.BindPFlag("Incsearch", rootCmd.PersistentFlags().Lookup("incsearch"))
 
+	rootCmd.PersistentFlags().BoolP("fuzzy-search", "", false, "fuzzy search")
+	_ = viper.BindPFlag("FuzzySearch", rootCmd.PersistentFlags().Lookup("fuzzy-search"))
+
+	rootCmd.PersistentFlags().BoolP("expr-search", "", false, "search expression with AND, OR and NOT")
+	_ = viper.BindPFlag("ExprSearch", rootCmd.PersistentFlags().Lookup("expr-search"))
+
+	rootCmd.PersistentFlags().BoolP("raw-search", "", false, "search the original bytes including escape sequences")
+	_ = viper.BindPFlag("RawSearch", rootCmd.PersistentFlags().Lookup("raw-search"))
+
+	rootCmd.PersistentFlags().StringP("search-normalization", "", "", "Unicode normalization of search [NFC|NFKC]")
+	_ = viper.BindPFlag("SearchNormalization", rootCmd.PersistentFlags().Lookup("search-normalization"))
+	_ = rootCmd.RegisterFlagCompletionFunc("search-normalization", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return []string{"NFC", "NFKC"}, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	rootCmd.PersistentFlags().BoolP("ignore-diacritics", "", false, "ignore diacritical marks in search")
+	_ = viper.BindPFlag("IgnoreDiacritics", rootCmd.PersistentFlags().Lookup("ignore-diacritics"))
+
+	rootCmd.PersistentFlags().BoolP("global-search", "", false, "continue the search into the other documents")
+	_ = viper.BindPFlag("GlobalSearch", rootCmd.PersistentFlags().Lookup("global-search"))
+
+	rootCmd.PersistentFlags().BoolP("global-search-filtered", "", false, "global search also searches the filter and occur documents")
+	_ = viper.BindPFlag("GlobalSearchFiltered", rootCmd.PersistentFlags().Lookup("global-search-filtered"))
+
+	rootCmd.PersistentFlags().BoolP("wrap-search", "", false, "continue the search from the other end of the document")
+	_ = viper.BindPFlag("WrapSearch", rootCmd.PersistentFlags().Lookup("wrap-search"))
+
+	rootCmd.PersistentFlags().BoolP("section-search", "", false, "search in the current section and filter by section")
+	_ = viper.BindPFlag("SectionSearch", rootCmd.PersistentFlags().Lookup("section-search"))
+
+	rootCmd.PersistentFlags().BoolP("search-index", "", false, "build an index to speed up repeated searches")
+	_ = viper.BindPFlag("SearchIndex", rootCmd.PersistentFlags().Lookup("search-index"))
+
 	rootCmd.PersistentFlags().IntP("memory-limit", "", -1, "number of chunks to limit in memory")
 	_ = viper.BindPFlag("MemoryLimit", rootCmd.PersistentFlags().Lookup("memory-limit"))
 
@@ -556,6 +665,9 @@ func init() {
 	rootCmd.PersistentFlags().BoolP("disable-mouse", "", false, "disable mouse support")
 	_ = viper.BindPFlag("DisableMouse", rootCmd.PersistentFlags().Lookup("disable-mouse"))
 
+	rootCmd.PersistentFlags().BoolP("disable-history", "", false, "disable saving the input history")
+	_ = viper.BindPFlag("DisableHistory", rootCmd.PersistentFlags().Lookup("disable-history"))
+
 	rootCmd.PersistentFlags().BoolP("disable-column-cycle", "", false, "disable column cycling")
 	_ = viper.BindPFlag("DisableColumnCycle", rootCmd.PersistentFlags().Lookup("disable-column-cycle"))
 
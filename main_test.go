@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"io"
 	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
 )
 
@@ -74,3 +76,24 @@ func Test_initConfig(t *testing.T) {
 		})
 	}
 }
+
+func Test_addMultiColorFile(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "words.txt")
+	if err := os.WriteFile(fileName, []byte("# comment\nERROR\n\nWARN\r\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	config.General.SetMultiColorWords([]string{"INFO"})
+	defer func() {
+		config.General.MultiColorWords = nil
+	}()
+	if err := addMultiColorFile(fileName); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"INFO", "ERROR", "WARN"}
+	if got := *config.General.MultiColorWords; !reflect.DeepEqual(got, want) {
+		t.Errorf("addMultiColorFile() = %v, want %v", got, want)
+	}
+	if err := addMultiColorFile(filepath.Join(t.TempDir(), "none")); err == nil {
+		t.Errorf("addMultiColorFile() error = nil, want error")
+	}
+}
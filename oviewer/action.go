@@ -32,6 +32,19 @@ func (root *Root) toggleWrapMode(context.Context) {
 	root.setMessagef("Set WrapMode %t", m.WrapMode)
 }
 
+// toggleTableWrap toggles TableWrap each time it is called.
+// TableWrap wraps long cells within their column width in align mode.
+func (root *Root) toggleTableWrap(context.Context) {
+	m := root.Doc
+	m.TableWrap = !m.TableWrap
+	if m.TableWrap && !m.WrapMode {
+		m.WrapMode = true
+	}
+	m.ClearCache()
+	root.resetSelect()
+	root.setMessagef("Set TableWrap %t", m.TableWrap)
+}
+
 // toggleColumnMode toggles ColumnMode each time it is called.
 func (root *Root) toggleColumnMode(context.Context) {
 	root.Doc.ColumnMode = !root.Doc.ColumnMode
@@ -216,6 +229,11 @@ func (root *Root) searchGo(ctx context.Context, lN int, searcher Searcher) {
 	root.setPauseFollow()
 	root.resetSelect()
 	root.Doc.lastSearchLN = lN
+	// The search slots do not change the match count of the search.
+	if _, ok := searcher.(slotSearcher); !ok {
+		root.Doc.matchLN = lN
+		root.Doc.startMatchCount(ctx, searcher, lN, root.sendMatchCount)
+	}
 	start, end := root.searchXPos(lN, searcher)
 	if root.Doc.jumpTargetSection {
 		root.Doc.searchGoSection(ctx, lN, start, end)
@@ -476,7 +494,7 @@ func (root *Root) modeConfig(modeName string) (RunTimeSettings, error) {
 		return settings, nil
 	}
 
-	viewMode, ok := root.Config.Mode[modeName]
+	viewMode, ok := lookupViewMode(root.Config, modeName)
 	if !ok {
 		return RunTimeSettings{}, fmt.Errorf("%w: %s", ErrInvalidModeName, modeName)
 	}
@@ -642,6 +660,17 @@ func (root *Root) setMultiColor(input string) {
 	root.setMessagef("Set multicolor strings [%s]", input)
 }
 
+// setLogfmtHideKeys sets the keys not to display in the logfmt converter.
+// An empty input displays all keys.
+func (root *Root) setLogfmtHideKeys(input string) {
+	keys := strings.FieldsFunc(input, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+	root.Doc.LogfmtHideKeys = keys
+	root.Doc.ClearCache()
+	root.setMessagef("Set hide keys [%s]", strings.Join(keys, ","))
+}
+
 // setJumpTarget sets the position of the search result.
 func (root *Root) setJumpTarget(input string) {
 	if input == "" {
@@ -0,0 +1,130 @@
+package oviewer
+
+import (
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// separatorWidths looks for a separator line in the header lines
+// and the line just below them (the underline of the header),
+// and returns the column boundaries derived from it.
+// A separator line is a dashed underline such as "----- ------ ---"
+// or a border line of a table such as "+----+----+" or "├────┼────┤".
+// The lines below the header are not looked at, because they may be ordinary content.
+func separatorWidths(lines []string, header int) ([]int, bool) {
+	for _, line := range lines[:min(len(lines), max(header, 0)+1)] {
+		if widths, ok := separatorPositions(line); ok {
+			return widths, true
+		}
+	}
+	return nil, false
+}
+
+// separatorPositions returns the column boundaries of the separator line.
+// If the line contains junction characters ('+', '|', '┼', etc.),
+// their positions are the boundaries,
+// except for the borders at the beginning and the end of the line.
+// Otherwise, the last blank before each dashed run is the boundary.
+func separatorPositions(line string) ([]int, bool) {
+	line = strings.TrimRight(line, " \r\n")
+	var junctions, gaps []int
+	dashes := 0
+	x, lastX := 0, 0
+	prevBlank := false
+	for _, r := range line {
+		switch {
+		case isSeparatorDash(r):
+			dashes++
+			if prevBlank && dashes > 1 {
+				gaps = append(gaps, x-1)
+			}
+			prevBlank = false
+		case isSeparatorJunction(r):
+			junctions = append(junctions, x)
+			prevBlank = false
+		case r == ' ':
+			prevBlank = true
+		default:
+			return nil, false
+		}
+		lastX = x
+		x += runewidth.RuneWidth(r)
+	}
+	if dashes < 3 {
+		return nil, false
+	}
+	if len(junctions) > 0 {
+		return innerJunctions(junctions, lastX)
+	}
+	if len(gaps) > 0 {
+		return gaps, true
+	}
+	return nil, false
+}
+
+// innerJunctions returns the junctions excluding the outer borders at 0 and lastX.
+// The outer borders would make empty columns.
+func innerJunctions(junctions []int, lastX int) ([]int, bool) {
+	inner := make([]int, 0, len(junctions))
+	for _, j := range junctions {
+		if j == 0 || j == lastX {
+			continue
+		}
+		inner = append(inner, j)
+	}
+	if len(inner) == 0 {
+		return nil, false
+	}
+	return inner, true
+}
+
+// isSeparatorDash returns true if the rune is a horizontal line of the separator line.
+func isSeparatorDash(r rune) bool {
+	switch r {
+	case '-', '=', ':', '─', '━', '═', '╌', '┄':
+		return true
+	}
+	return false
+}
+
+// isSeparatorJunction returns true if the rune is a junction of the separator line.
+func isSeparatorJunction(r rune) bool {
+	switch r {
+	case '+', '┼', '├', '┤', '┌', '┐', '└', '┘', '┬', '┴', '╋', '╪', '╬', '╞', '╡', '╤', '╧':
+		return true
+	}
+	return isColumnBorder(r)
+}
+
+// isColumnBorder returns true if the rune is a vertical line that separates columns.
+func isColumnBorder(r rune) bool {
+	switch r {
+	case '|', '│', '┃', '║':
+		return true
+	}
+	return false
+}
+
+// outerBorders returns the range of lc excluding the column borders
+// at the beginning and the end of the line, such as "│ a │ b │".
+// Returns 0 and len(lc) if there are no outer borders.
+func outerBorders(lc contents) (int, int) {
+	start, end := 0, len(lc)
+	if s := findStartWithTrim(lc, 0); s < end && isColumnBorder(lc[s].mainc) {
+		start = s + 1
+	}
+	if e := findEndWidthTrim(lc, end); e > start && isColumnBorder(lc[e-1].mainc) {
+		end = e - 1
+	}
+	return start, end
+}
+
+// trimBorder returns the end of the column excluding the column border.
+// Returns true if the column ends with a column border.
+func trimBorder(lc contents, start int, end int) (int, bool) {
+	if end > start && end <= len(lc) && isColumnBorder(lc[end-1].mainc) {
+		return end - 1, true
+	}
+	return end, false
+}
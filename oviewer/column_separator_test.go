@@ -0,0 +1,187 @@
+package oviewer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_separatorPositions(t *testing.T) {
+	type args struct {
+		line string
+	}
+	tests := []struct {
+		name  string
+		args  args
+		want  []int
+		want1 bool
+	}{
+		{
+			name: "dashed",
+			args: args{
+				line: "-----  ------ ---",
+			},
+			want:  []int{6, 13},
+			want1: true,
+		},
+		{
+			name: "psql",
+			args: args{
+				line: "-----+------+---",
+			},
+			want:  []int{5, 12},
+			want1: true,
+		},
+		{
+			name: "box",
+			args: args{
+				line: "├──┼───┤",
+			},
+			want:  []int{3},
+			want1: true,
+		},
+		{
+			name: "newline",
+			args: args{
+				line: "+----+-----+\n",
+			},
+			want:  []int{5},
+			want1: true,
+		},
+		{
+			name: "boxWide",
+			args: args{
+				line: "│───│────│──│",
+			},
+			want:  []int{4, 9},
+			want1: true,
+		},
+		{
+			name: "outerBorderOnly",
+			args: args{
+				line: "+--------+",
+			},
+			want:  nil,
+			want1: false,
+		},
+		{
+			name: "notSeparator",
+			args: args{
+				line: "NAME   READY  STATUS",
+			},
+			want:  nil,
+			want1: false,
+		},
+		{
+			name: "shortDash",
+			args: args{
+				line: "- -",
+			},
+			want:  nil,
+			want1: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1 := separatorPositions(tt.args.line)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("separatorPositions() got = %v, want %v", got, tt.want)
+			}
+			if got1 != tt.want1 {
+				t.Errorf("separatorPositions() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
+
+func Test_separatorWidths(t *testing.T) {
+	type args struct {
+		lines  []string
+		header int
+	}
+	tests := []struct {
+		name  string
+		args  args
+		want  []int
+		want1 bool
+	}{
+		{
+			name: "underline",
+			args: args{
+				lines:  []string{"NAME  VALUE", "----- -----", "a     1"},
+				header: 1,
+			},
+			want:  []int{5},
+			want1: true,
+		},
+		{
+			name: "boxHeader",
+			args: args{
+				lines:  []string{"+----+-----+", "| ab | cde |", "+----+-----+", "| 1  | 2   |"},
+				header: 3,
+			},
+			want:  []int{5},
+			want1: true,
+		},
+		{
+			name: "topBorderNoHeader",
+			args: args{
+				lines:  []string{"+----+-----+", "| 1  | 2   |"},
+				header: 0,
+			},
+			want:  []int{5},
+			want1: true,
+		},
+		{
+			name: "underlineNoHeader",
+			args: args{
+				lines:  []string{"NAME  VALUE", "----- -----", "a     1"},
+				header: 0,
+			},
+			want:  nil,
+			want1: false,
+		},
+		{
+			name: "content",
+			args: args{
+				lines:  []string{"start server", "listen :80", "-------- ----", "ready"},
+				header: 1,
+			},
+			want:  nil,
+			want1: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1 := separatorWidths(tt.args.lines, tt.args.header)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("separatorWidths() got = %v, want %v", got, tt.want)
+			}
+			if got1 != tt.want1 {
+				t.Errorf("separatorWidths() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
+
+func Test_outerBorders(t *testing.T) {
+	tests := []struct {
+		name  string
+		str   string
+		want  int
+		want1 int
+	}{
+		{name: "box", str: "│ a │ b │", want: 1, want1: 8},
+		{name: "ascii", str: "  | a | b |  ", want: 3, want1: 10},
+		{name: "noBorder", str: "a | b", want: 0, want1: 5},
+		{name: "borderOnly", str: "|", want: 1, want1: 1},
+		{name: "empty", str: "", want: 0, want1: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1 := outerBorders(StrToContents(tt.str, 8))
+			if got != tt.want || got1 != tt.want1 {
+				t.Errorf("outerBorders() = %v, %v, want %v, %v", got, got1, tt.want, tt.want1)
+			}
+		})
+	}
+}
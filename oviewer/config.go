@@ -44,6 +44,10 @@ type Config struct {
 	MemoryLimitFile int
 	// DisableMouse indicates whether mouse support is disabled.
 	DisableMouse bool
+	// DisableHistory indicates whether saving the input history to a file is disabled.
+	DisableHistory bool
+	// HistorySize is the maximum number of entries of each input history.
+	HistorySize int
 
 	// IsWriteOnExit indicates whether to write the current screen on exit.
 	IsWriteOnExit bool
@@ -59,6 +63,28 @@ type Config struct {
 	SmartCaseSensitive bool
 	// RegexpSearch indicates whether to use regular expression search.
 	RegexpSearch bool
+	// FuzzySearch indicates whether to use fuzzy search.
+	FuzzySearch bool
+	// ExprSearch indicates whether to treat a search word containing AND, OR or NOT as a search expression.
+	ExprSearch bool
+	// RawSearch indicates whether to search the original bytes of the lines, including escape sequences.
+	RawSearch bool
+	// SearchNormalization is the Unicode normalization form ("NFC" or "NFKC") applied to the search word and the lines.
+	SearchNormalization string
+	// IgnoreDiacritics indicates whether to ignore diacritical marks in search.
+	IgnoreDiacritics bool
+	// SearchIndex indicates whether to build the index to skip the chunks that do not match the search.
+	SearchIndex bool
+	// GlobalSearch indicates whether the search continues into the other documents.
+	GlobalSearch bool
+	// GlobalSearchFiltered indicates whether global search also searches the filter and occur documents.
+	GlobalSearchFiltered bool
+	// WrapSearch indicates whether the search continues from the beginning (the end for backward search)
+	// after reaching the end of the document.
+	WrapSearch bool
+	// SectionSearch indicates whether the search is limited to the current section,
+	// and the filter outputs the whole sections containing the matching lines.
+	SectionSearch bool
 	// Incsearch indicates whether to use incremental search.
 	Incsearch bool
 	// NotifyEOF specifies the number of times to notify EOF.
@@ -158,6 +184,7 @@ func NewConfig() Config {
 		MemoryLimit:     -1,
 		MemoryLimitFile: 100,
 		ReadWaitTime:    1000 * time.Millisecond,
+		HistorySize:     defaultHistorySize,
 	}
 }
 
@@ -175,6 +202,10 @@ type StyleConfig struct {
 	LineNumber *OVStyle
 	// SearchHighlight is the style that applies to the search highlight.
 	SearchHighlight *OVStyle
+	// SearchGroupHighlight is the style that applies to the capture groups of the regular expression search.
+	SearchGroupHighlight *[]OVStyle
+	// SearchSlotHighlight is the style that applies to each search slot.
+	SearchSlotHighlight *[]OVStyle
 	// ColumnHighlight is the style that applies to the column highlight.
 	ColumnHighlight *OVStyle
 	// MarkLine is a style that marked line.
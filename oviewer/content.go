@@ -111,7 +111,31 @@ func parseString(conv Converter, str string, tabWidth int) contents {
 
 // parseLine converts a string to lineContents and eolStyle, and returns them.
 func parseLine(conv Converter, str string, tabWidth int) (contents, tcell.Style) {
-	st := &parseState{
+	st := newParseState(str, tabWidth)
+	st.parse(conv, str, nil)
+	return st.lc, st.eolStyle
+}
+
+// contentsPos returns the position of the contents converted from each byte of str.
+// starts[i] is the position of the contents of the character containing byte i,
+// and ends[i] is the position after the contents of the character containing byte i-1.
+func contentsPos(conv Converter, str string, tabWidth int) ([]int, []int) {
+	starts := make([]int, len(str)+1)
+	ends := make([]int, len(str)+1)
+	st := newParseState(str, tabWidth)
+	st.parse(conv, str, func(from int, to int, start int, end int) {
+		for i := from; i < to; i++ {
+			starts[i] = start
+			ends[i+1] = end
+		}
+	})
+	starts[len(str)] = len(st.lc)
+	return starts, ends
+}
+
+// newParseState returns the parseState to parse str.
+func newParseState(str string, tabWidth int) *parseState {
+	return &parseState{
 		lc:        make(contents, 0, len(str)),
 		style:     tcell.StyleDefault,
 		eolStyle:  tcell.StyleDefault,
@@ -120,22 +144,30 @@ func parseLine(conv Converter, str string, tabWidth int) (contents, tcell.Style)
 		bsFlag:    false,
 		bsContent: DefaultContent,
 	}
+}
 
+// parse parses str into contents.
+// If fn is not nil, it is called for each character with the byte range of str
+// and the range of the contents converted from it.
+func (st *parseState) parse(conv Converter, str string, fn func(from int, to int, start int, end int)) {
 	gr := uniseg.NewGraphemes(str)
 	for gr.Next() {
 		r := gr.Runes()
 		st.mainc = r[0]
 		st.combc = r[1:]
 
-		if conv.convert(st) {
-			continue
+		start := len(st.lc)
+		if !conv.convert(st) {
+			st.parseChar(st.mainc, st.combc)
+		}
+		if fn != nil {
+			from, to := gr.Positions()
+			fn(from, to, start, len(st.lc))
 		}
-		st.parseChar(st.mainc, st.combc)
 	}
 	st.mainc = '\n'
 	st.combc = nil
 	conv.convert(st)
-	return st.lc, st.eolStyle
 }
 
 // parseChar parses a single character.
@@ -15,7 +15,14 @@ func setup() {
 
 func TestMain(m *testing.M) {
 	setup()
+	// Do not read or write the input history of the user.
+	stateDir, err := os.MkdirTemp("", "ov-test-state")
+	if err != nil {
+		panic(err)
+	}
+	os.Setenv("XDG_STATE_HOME", stateDir)
 	ret := m.Run()
+	os.RemoveAll(stateDir)
 	os.Exit(ret)
 }
 
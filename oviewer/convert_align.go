@@ -17,6 +17,9 @@ type align struct {
 	delimiter    string
 	delimiterReg *regexp.Regexp
 	count        int
+	// wrapWidth is the width to wrap cells within columns (table wrap).
+	// 0 disables table wrap.
+	wrapWidth int
 }
 
 // specifiedAlign represents the alignment specification for a column.
@@ -88,9 +91,12 @@ func (a *align) convert(st *parseState) bool {
 		return false
 	}
 
-	if a.WidthF {
+	switch {
+	case a.wrapWidth > 0:
+		st.lc = a.convertTableWrap(st.lc)
+	case a.WidthF:
 		st.lc = a.convertWidth(st.lc)
-	} else {
+	default:
 		st.lc = a.convertDelm(st.lc)
 	}
 	return false
@@ -139,10 +145,14 @@ func (a *align) convertDelm(src contents) contents {
 func (a *align) convertWidth(src contents) contents {
 	dst := make(contents, 0, len(src))
 
-	start := 0
+	// The outer borders of the table are not a part of the cells.
+	start, lEnd := outerBorders(src)
+	if start > 0 {
+		dst = append(dst, src[start-1])
+	}
 	for columnNum := range a.orgWidths {
 		end := findColumnEnd(src, a.orgWidths, columnNum, start) + 1
-		end = min(end, len(src))
+		end = max(min(end, lEnd), start)
 
 		// shrink column.
 		if a.isShrink(columnNum) {
@@ -154,24 +164,55 @@ func (a *align) convertWidth(src contents) contents {
 		}
 
 		// content column.
+		// The column border is used as a separator instead of a space.
+		separator := SpaceContent
+		cEnd, border := trimBorder(src, start, end)
+		if border {
+			separator = src[cEnd]
+		}
 		tStart := findStartWithTrim(src, start)
-		tEnd := findEndWidthTrim(src, end)
+		tEnd := findEndWidthTrim(src, cEnd)
 		// If the column width is 0, skip.
 		if tStart >= tEnd {
+			if border {
+				dst = a.appendColumn(dst, columnNum, nil)
+				dst = append(dst, separator)
+			}
 			start = end
 			continue
 		}
 		dst = a.appendColumn(dst, columnNum, src[tStart:tEnd])
-		dst = append(dst, SpaceContent)
+		dst = append(dst, separator)
 		start = end
 	}
 
 	// Add the remaining content.
 	if a.isShrink(len(a.orgWidths)) {
 		dst = appendShrink(dst)
-		return dst
+		return appendBorder(dst, src, lEnd)
+	}
+	if lEnd == len(src) {
+		return a.appendColumn(dst, len(a.orgWidths), src[start:])
+	}
+	dst = a.appendColumn(dst, len(a.orgWidths), trimContents(src, start, lEnd))
+	return appendBorder(dst, src, lEnd)
+}
+
+// trimContents returns src[start:end] without the spaces on both sides.
+func trimContents(src contents, start int, end int) contents {
+	tStart := findStartWithTrim(src, start)
+	tEnd := findEndWidthTrim(src, end)
+	if tStart >= tEnd {
+		return nil
+	}
+	return src[tStart:tEnd]
+}
+
+// appendBorder adds the outer border at the end of src, which starts at end.
+func appendBorder(dst contents, src contents, end int) contents {
+	if end < len(src) {
+		dst = append(dst, src[end])
 	}
-	dst = a.appendColumn(dst, len(a.orgWidths), src[start:])
 	return dst
 }
 
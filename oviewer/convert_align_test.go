@@ -425,6 +425,20 @@ func Test_align_convertWidth(t *testing.T) {
 			},
 			want: "  a b   c   d   e   … ",
 		},
+		{
+			name: "convertAlignWidthBorder",
+			fields: fields{
+				es:        newESConverter(),
+				maxWidths: []int{2, 3},
+				orgWidths: []int{5},
+				WidthF:    true,
+				count:     0,
+			},
+			args: args{
+				src: StrToContents("│ a  │ bb │", 8),
+			},
+			want: "│a │bb │",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
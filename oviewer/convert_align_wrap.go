@@ -0,0 +1,219 @@
+package oviewer
+
+// alignCell is a cell of one line split by the align converter.
+type alignCell struct {
+	// lc is the contents of the cell.
+	lc contents
+	// delm is the delimiter following the cell.
+	delm contents
+	// columnNum is the column number of the cell.
+	columnNum int
+}
+
+// convertTableWrap aligns the columns and wraps long cells within their column width.
+// The result is padded so that each physical row fits exactly in wrapWidth,
+// so that the normal wrap processing splits the line at the row boundaries.
+func (a *align) convertTableWrap(src contents) contents {
+	var border contents
+	var cells []alignCell
+	if a.WidthF {
+		border, cells = a.widthCells(src)
+	} else {
+		cells = a.delmCells(src)
+	}
+	if len(cells) == 0 {
+		return src
+	}
+
+	widths := make([]int, len(cells))
+	delmWidth := 0
+	for i, cell := range cells {
+		widths[i] = a.cellWidth(cell)
+		delmWidth += len(cell.delm)
+	}
+	available := a.wrapWidth - delmWidth - len(border)
+	// Not enough width to wrap in columns.
+	if available < len(cells) {
+		if a.WidthF {
+			return a.convertWidth(src)
+		}
+		return a.convertDelm(src)
+	}
+	widths = fitWidths(widths, available)
+
+	pieces := make([][]contents, len(cells))
+	rows := 1
+	for i, cell := range cells {
+		pieces[i] = splitContents(cell.lc, widths[i])
+		rows = max(rows, len(pieces[i]))
+	}
+
+	dst := make(contents, 0, rows*a.wrapWidth)
+	for row := range rows {
+		start := len(dst)
+		if row == 0 {
+			dst = append(dst, border...)
+		} else {
+			dst = appendPaddings(dst, len(border))
+		}
+		for i, cell := range cells {
+			var piece contents
+			if row < len(pieces[i]) {
+				piece = pieces[i][row]
+			}
+			dst = a.appendCell(dst, cell.columnNum, piece, widths[i])
+			if row == 0 {
+				dst = append(dst, cell.delm...)
+			} else {
+				dst = appendPaddings(dst, len(cell.delm))
+			}
+		}
+		// The last row does not need to be filled.
+		if row < rows-1 {
+			dst = appendPaddings(dst, a.wrapWidth-(len(dst)-start))
+		}
+	}
+	return dst
+}
+
+// delmCells splits the line into cells by the delimiter.
+func (a *align) delmCells(src contents) []alignCell {
+	str, pos := ContentsToStr(src)
+	indexes := allIndex(str, a.delimiter, a.delimiterReg)
+	if len(indexes) == 0 {
+		return nil
+	}
+	cells := make([]alignCell, 0, len(indexes)+1)
+	start := pos.x(0)
+	for columnNum := range indexes {
+		end := pos.x(indexes[columnNum][0])
+		delmEnd := pos.x(indexes[columnNum][1])
+		cells = append(cells, a.newCell(columnNum, src[start:end], src[end:delmEnd]))
+		start = delmEnd
+	}
+	cells = append(cells, a.newCell(len(indexes), src[start:], nil))
+	return cells
+}
+
+// widthCells splits the line into cells by the column widths.
+// It also returns the outer border at the beginning of the line, which is not a part of the cells.
+// The outer border at the end of the line is the delimiter of the last cell.
+func (a *align) widthCells(src contents) (contents, []alignCell) {
+	if len(a.orgWidths) == 0 {
+		return nil, nil
+	}
+	cells := make([]alignCell, 0, len(a.orgWidths)+1)
+	start, lEnd := outerBorders(src)
+	var border contents
+	if start > 0 {
+		border = src[start-1 : start]
+	}
+	for columnNum := range a.orgWidths {
+		end := findColumnEnd(src, a.orgWidths, columnNum, start) + 1
+		end = max(min(end, lEnd), start)
+		separator := SpaceContent
+		cEnd, border := trimBorder(src, start, end)
+		if border {
+			separator = src[cEnd]
+		}
+		tStart := findStartWithTrim(src, start)
+		tEnd := findEndWidthTrim(src, cEnd)
+		var column contents
+		if tStart < tEnd {
+			column = src[tStart:tEnd]
+		}
+		cells = append(cells, a.newCell(columnNum, column, contents{separator}))
+		start = end
+	}
+	if lEnd == len(src) {
+		cells = append(cells, a.newCell(len(a.orgWidths), src[min(start, len(src)):], nil))
+		return border, cells
+	}
+	cells = append(cells, a.newCell(len(a.orgWidths), trimContents(src, start, lEnd), src[lEnd:lEnd+1]))
+	return border, cells
+}
+
+// newCell returns a cell, replacing the contents if the column is shrunk.
+func (a *align) newCell(columnNum int, column contents, delm contents) alignCell {
+	if a.isShrink(columnNum) {
+		column = appendShrink(nil)
+	}
+	return alignCell{lc: column, delm: delm, columnNum: columnNum}
+}
+
+// cellWidth returns the aligned width of the cell.
+func (a *align) cellWidth(cell alignCell) int {
+	if a.isShrink(cell.columnNum) {
+		return len(cell.lc)
+	}
+	if cell.columnNum < len(a.maxWidths) {
+		return max(a.maxWidths[cell.columnNum], len(cell.lc))
+	}
+	return len(cell.lc)
+}
+
+// appendCell adds a piece of the cell padded to the width.
+func (a *align) appendCell(lc contents, columnNum int, piece contents, width int) contents {
+	padding := max(width-len(piece), 0)
+	if a.isRightAlign(columnNum) {
+		lc = appendPaddings(lc, padding)
+		return append(lc, piece...)
+	}
+	lc = append(lc, piece...)
+	return appendPaddings(lc, padding)
+}
+
+// fitWidths reduces the widest columns so that the total width fits in available.
+// Each column keeps a width of at least 1.
+func fitWidths(widths []int, available int) []int {
+	total := 0
+	for _, w := range widths {
+		total += w
+	}
+	if total <= available {
+		return widths
+	}
+
+	// Find the largest limit at which the total fits.
+	limit := 0
+	for _, w := range widths {
+		limit = max(limit, w)
+	}
+	for limit > 1 {
+		total = 0
+		for _, w := range widths {
+			total += min(w, limit)
+		}
+		if total <= available {
+			break
+		}
+		limit--
+	}
+
+	fitted := make([]int, len(widths))
+	for i, w := range widths {
+		fitted[i] = max(min(w, limit), 1)
+	}
+	return fitted
+}
+
+// splitContents splits contents into pieces of the specified width.
+// Full-width characters are not split.
+func splitContents(lc contents, width int) []contents {
+	if len(lc) <= width || width <= 0 {
+		return []contents{lc}
+	}
+	var pieces []contents
+	for len(lc) > width {
+		n := width
+		if lc.IsFullWidth(n-1) && n > 1 {
+			n--
+		}
+		pieces = append(pieces, lc[:n])
+		lc = lc[n:]
+	}
+	if len(lc) > 0 {
+		pieces = append(pieces, lc)
+	}
+	return pieces
+}
@@ -0,0 +1,222 @@
+package oviewer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_align_convertTableWrap(t *testing.T) {
+	type fields struct {
+		maxWidths []int
+		orgWidths []int
+		columns   []columnAttribute
+		WidthF    bool
+		delimiter string
+		wrapWidth int
+	}
+	type args struct {
+		src contents
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   string
+	}{
+		{
+			name: "noWrap",
+			fields: fields{
+				maxWidths: []int{2, 2, 2},
+				delimiter: ",",
+				wrapWidth: 20,
+			},
+			args: args{
+				src: StrToContents("a,b,c", 8),
+			},
+			want: "a ,b ,c ",
+		},
+		{
+			name: "wrapLongCell",
+			fields: fields{
+				maxWidths: []int{2, 10, 2},
+				delimiter: ",",
+				wrapWidth: 12,
+			},
+			args: args{
+				src: StrToContents("a,bbbbbbbbbb,c", 8),
+			},
+			// widths are fitted to 2,6,2 + 2 delimiters.
+			want: "a ,bbbbbb,c " +
+				"   bbbb     ",
+		},
+		{
+			name: "wrapRightAlign",
+			fields: fields{
+				maxWidths: []int{2, 10},
+				columns: []columnAttribute{
+					{rightAlign: true},
+					{},
+				},
+				delimiter: ",",
+				wrapWidth: 6,
+			},
+			args: args{
+				src: StrToContents("a,bbbbbbb", 8),
+			},
+			want: " a,bbb" +
+				"   bbb" +
+				"   b  ",
+		},
+		{
+			name: "wrapWidth",
+			fields: fields{
+				maxWidths: []int{1, 8},
+				orgWidths: []int{2},
+				WidthF:    true,
+				wrapWidth: 6,
+			},
+			args: args{
+				src: StrToContents("a  bbbbbbbb", 8),
+			},
+			want: "a bbbb" +
+				"  bbbb",
+		},
+		{
+			name: "wrapWidthBorder",
+			fields: fields{
+				maxWidths: []int{1, 4},
+				orgWidths: []int{4},
+				WidthF:    true,
+				wrapWidth: 7,
+			},
+			args: args{
+				src: StrToContents("│ a │ bbbb │", 8),
+			},
+			want: "│a│bbb│" +
+				"   b   ",
+		},
+		{
+			name: "tooNarrow",
+			fields: fields{
+				maxWidths: []int{2, 2, 2},
+				delimiter: ",",
+				wrapWidth: 3,
+			},
+			args: args{
+				src: StrToContents("a,b,c", 8),
+			},
+			want: "a ,b ,c ",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &align{
+				es:          newESConverter(),
+				maxWidths:   tt.fields.maxWidths,
+				orgWidths:   tt.fields.orgWidths,
+				columnAttrs: tt.fields.columns,
+				WidthF:      tt.fields.WidthF,
+				delimiter:   tt.fields.delimiter,
+				wrapWidth:   tt.fields.wrapWidth,
+			}
+			got := a.convertTableWrap(tt.args.src)
+			gotStr, _ := ContentsToStr(got)
+			if gotStr != tt.want {
+				t.Errorf("align.convertTableWrap() = %q, want %q", gotStr, tt.want)
+			}
+		})
+	}
+}
+
+func Test_fitWidths(t *testing.T) {
+	type args struct {
+		widths    []int
+		available int
+	}
+	tests := []struct {
+		name string
+		args args
+		want []int
+	}{
+		{
+			name: "fit",
+			args: args{
+				widths:    []int{2, 3, 4},
+				available: 10,
+			},
+			want: []int{2, 3, 4},
+		},
+		{
+			name: "reduceWidest",
+			args: args{
+				widths:    []int{2, 10, 4},
+				available: 10,
+			},
+			want: []int{2, 4, 4},
+		},
+		{
+			name: "minimum",
+			args: args{
+				widths:    []int{5, 5, 5},
+				available: 3,
+			},
+			want: []int{1, 1, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fitWidths(tt.args.widths, tt.args.available); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("fitWidths() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_splitContents(t *testing.T) {
+	type args struct {
+		lc    contents
+		width int
+	}
+	tests := []struct {
+		name string
+		args args
+		want []string
+	}{
+		{
+			name: "short",
+			args: args{
+				lc:    StrToContents("abc", 8),
+				width: 5,
+			},
+			want: []string{"abc"},
+		},
+		{
+			name: "split",
+			args: args{
+				lc:    StrToContents("abcdefg", 8),
+				width: 3,
+			},
+			want: []string{"abc", "def", "g"},
+		},
+		{
+			name: "fullWidth",
+			args: args{
+				lc:    StrToContents("aあいう", 8),
+				width: 4,
+			},
+			want: []string{"aあ", "いう"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitContents(tt.args.lc, tt.args.width)
+			gotStr := make([]string, len(got))
+			for i, lc := range got {
+				gotStr[i], _ = ContentsToStr(lc)
+			}
+			if !reflect.DeepEqual(gotStr, tt.want) {
+				t.Errorf("splitContents() = %v, want %v", gotStr, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package oviewer
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// fieldDelimiter is the delimiter of the columns converted from fields.
+const fieldDelimiter = "│"
+
+// fieldDelimiterContent is the content of fieldDelimiter.
+var fieldDelimiterContent = content{
+	mainc: '│',
+	combc: nil,
+	width: 1,
+	style: tcell.StyleDefault,
+}
+
+// field is a key and the range of its value in the line.
+type field struct {
+	key   string
+	start int
+	end   int
+}
+
+// fieldConverter is a converter that converts the fields of a line into columns.
+// The fields are extracted by parse (logfmt, regular expression, etc.).
+// The keys are columns in the order in which they appear,
+// so that the same key is always in the same column.
+type fieldConverter struct {
+	es *escapeSequence
+	// parse returns the fields of the line.
+	// Returns nil if the line does not match.
+	parse func(str string) []field
+	// mu protects keys and keyIndex while they are added in drawing,
+	// because the searchers read them in the background (e.g. counting matches).
+	mu sync.RWMutex
+	// keys is the list of keys in the order in which they appear.
+	keys []string
+	// keyIndex is the index of the key in keys.
+	keyIndex map[string]int
+	// hideKeys is the list of keys not to display.
+	hideKeys []string
+	// maxWidths is the maximum width of each key column.
+	maxWidths []int
+}
+
+func newFieldConverter(parse func(str string) []field) *fieldConverter {
+	return &fieldConverter{
+		es:       newESConverter(),
+		parse:    parse,
+		keyIndex: make(map[string]int),
+	}
+}
+
+// convert converts the fields into columns at the end of the line.
+// Returns true if it is an escape sequence and a non-printing character.
+func (f *fieldConverter) convert(st *parseState) bool {
+	if f.es.convert(st) {
+		return true
+	}
+	if st.mainc != '\n' {
+		return false
+	}
+	st.lc = f.convertLine(st.lc)
+	return false
+}
+
+// convertLine converts one line.
+// Lines that do not match are returned as they are.
+func (f *fieldConverter) convertLine(src contents) contents {
+	str, pos := ContentsToStr(src)
+	fields := f.parse(str)
+	if len(fields) == 0 {
+		return src
+	}
+	values := make([]contents, len(f.keys))
+	for _, fl := range fields {
+		n := f.addKey(fl.key)
+		for len(values) <= n {
+			values = append(values, nil)
+		}
+		values[n] = src[pos.x(fl.start):pos.x(fl.end)]
+	}
+	return f.appendColumns(make(contents, 0, len(src)), values)
+}
+
+// header returns the keys as columns.
+func (f *fieldConverter) header() contents {
+	values := make([]contents, len(f.keys))
+	for i, key := range f.keys {
+		values[i] = StrToContents(key, 0)
+	}
+	return f.appendColumns(nil, values)
+}
+
+// appendColumns adds the values of the displayed keys separated by fieldDelimiter.
+func (f *fieldConverter) appendColumns(lc contents, values []contents) contents {
+	last := f.lastVisible()
+	for i := range f.keys {
+		if f.isHidden(i) {
+			continue
+		}
+		var value contents
+		if i < len(values) {
+			value = values[i]
+		}
+		lc = append(lc, value...)
+		if i == last {
+			break
+		}
+		if i < len(f.maxWidths) {
+			lc = appendPaddings(lc, f.maxWidths[i]-len(value))
+		}
+		lc = append(lc, fieldDelimiterContent)
+	}
+	return lc
+}
+
+// lastVisible returns the index of the last displayed key.
+func (f *fieldConverter) lastVisible() int {
+	for i := len(f.keys) - 1; i >= 0; i-- {
+		if !f.isHidden(i) {
+			return i
+		}
+	}
+	return -1
+}
+
+// isHidden returns true if the key of the index is hidden.
+func (f *fieldConverter) isHidden(n int) bool {
+	return slices.Contains(f.hideKeys, f.keys[n])
+}
+
+// addKey adds the key if it is new and returns the index of the key.
+func (f *fieldConverter) addKey(key string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if n, ok := f.keyIndex[key]; ok {
+		return n
+	}
+	f.keys = append(f.keys, key)
+	f.keyIndex[key] = len(f.keys) - 1
+	return len(f.keys) - 1
+}
+
+// keyNum returns the index of the key.
+// It can be called from a goroutine other than the one adding the keys.
+func (f *fieldConverter) keyNum(key string) (int, bool) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	n, ok := f.keyIndex[key]
+	return n, ok
+}
+
+// columnWidths updates the maximum width of each key column with the line.
+func (f *fieldConverter) columnWidths(maxWidths []int, lc contents) []int {
+	str, pos := ContentsToStr(lc)
+	for _, fl := range f.parse(str) {
+		n := f.addKey(fl.key)
+		maxWidths = f.keyWidths(maxWidths, n)
+		maxWidths[n] = max(maxWidths[n], pos.x(fl.end)-pos.x(fl.start))
+	}
+	return maxWidths
+}
+
+// keyWidths extends maxWidths up to n with the width of the keys.
+func (f *fieldConverter) keyWidths(maxWidths []int, n int) []int {
+	for len(maxWidths) <= n {
+		maxWidths = append(maxWidths, len(StrToContents(f.keys[len(maxWidths)], 0)))
+	}
+	return maxWidths
+}
@@ -0,0 +1,126 @@
+package oviewer
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func Test_fieldConverter_convertLine(t *testing.T) {
+	type fields struct {
+		hideKeys  []string
+		maxWidths []int
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		lines      []string
+		want       []string
+		wantHeader string
+	}{
+		{
+			name:   "columns",
+			fields: fields{},
+			lines: []string{
+				"level=info msg=start",
+				"msg=stop level=warn",
+			},
+			want: []string{
+				"info│start",
+				"warn│stop",
+			},
+			wantHeader: "level│msg",
+		},
+		{
+			name: "newKey",
+			fields: fields{
+				maxWidths: []int{5, 5},
+			},
+			lines: []string{
+				"level=info msg=start",
+				`dur=1ms msg="a b"`,
+			},
+			want: []string{
+				"info │start",
+				"     │a b  │1ms",
+			},
+			wantHeader: "level│msg  │dur",
+		},
+		{
+			name: "hideKeys",
+			fields: fields{
+				hideKeys:  []string{"msg"},
+				maxWidths: []int{5, 5, 3},
+			},
+			lines: []string{
+				"level=info msg=start dur=1ms",
+			},
+			want: []string{
+				"info │1ms",
+			},
+			wantHeader: "level│dur",
+		},
+		{
+			name:   "notLogfmt",
+			fields: fields{},
+			lines: []string{
+				"plain text",
+			},
+			want: []string{
+				"plain text",
+			},
+			wantHeader: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newLogfmtConverter()
+			l.hideKeys = tt.fields.hideKeys
+			l.maxWidths = tt.fields.maxWidths
+			for i, line := range tt.lines {
+				got := parseString(l, line, 8)
+				gotStr, _ := ContentsToStr(got)
+				if gotStr != tt.want[i] {
+					t.Errorf("fieldConverter.convertLine() = %q, want %q", gotStr, tt.want[i])
+				}
+			}
+			gotHeader, _ := ContentsToStr(l.header())
+			if gotHeader != tt.wantHeader {
+				t.Errorf("fieldConverter.header() = %q, want %q", gotHeader, tt.wantHeader)
+			}
+		})
+	}
+}
+
+func Test_fieldConverter_columnWidths(t *testing.T) {
+	l := newLogfmtConverter()
+	var widths []int
+	widths = l.columnWidths(widths, StrToContents("level=info msg=start", 8))
+	widths = l.columnWidths(widths, StrToContents("level=warning", 8))
+	want := []int{7, 5}
+	if !reflect.DeepEqual(widths, want) {
+		t.Errorf("fieldConverter.columnWidths() = %v, want %v", widths, want)
+	}
+	if !reflect.DeepEqual(l.keys, []string{"level", "msg"}) {
+		t.Errorf("fieldConverter.keys = %v", l.keys)
+	}
+}
+
+func Test_fieldConverter_keyNumConcurrent(t *testing.T) {
+	l := newLogfmtConverter()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := range 1000 {
+			l.addKey("key" + strconv.Itoa(i))
+		}
+	}()
+	// The searchers read the keys in the background while the keys are added.
+	for i := range 1000 {
+		l.keyNum("key" + strconv.Itoa(i))
+	}
+	<-done
+	if n, ok := l.keyNum("key999"); !ok || n != 999 {
+		t.Errorf("fieldConverter.keyNum() = %v, %v, want 999, true", n, ok)
+	}
+}
@@ -0,0 +1,67 @@
+package oviewer
+
+// newLogfmtConverter returns a converter that converts logfmt (key=value) lines into columns.
+func newLogfmtConverter() *fieldConverter {
+	return newFieldConverter(parseLogfmt)
+}
+
+// parseLogfmt parses the logfmt line and returns the keys and the ranges of the values.
+// Quoted values are returned without quotes.
+// Returns nil if the line is not logfmt.
+func parseLogfmt(str string) []field {
+	var fields []field
+	hasValue := false
+	i := 0
+	for i < len(str) {
+		if isLogfmtSpace(str[i]) {
+			i++
+			continue
+		}
+		keyStart := i
+		for i < len(str) && str[i] != '=' && str[i] != '"' && !isLogfmtSpace(str[i]) {
+			i++
+		}
+		key := str[keyStart:i]
+		if key == "" {
+			return nil
+		}
+		// A key without a value.
+		if i >= len(str) || str[i] != '=' {
+			if i < len(str) && str[i] == '"' {
+				return nil
+			}
+			fields = append(fields, field{key: key, start: i, end: i})
+			continue
+		}
+		i++ // '='
+		hasValue = true
+		if i < len(str) && str[i] == '"' {
+			i++
+			start := i
+			for i < len(str) && str[i] != '"' {
+				if str[i] == '\\' {
+					i++
+				}
+				i++
+			}
+			end := min(i, len(str))
+			fields = append(fields, field{key: key, start: start, end: end})
+			i = min(i+1, len(str))
+			continue
+		}
+		start := i
+		for i < len(str) && !isLogfmtSpace(str[i]) {
+			i++
+		}
+		fields = append(fields, field{key: key, start: start, end: i})
+	}
+	if !hasValue {
+		return nil
+	}
+	return fields
+}
+
+// isLogfmtSpace returns true if the byte separates the logfmt pairs.
+func isLogfmtSpace(b byte) bool {
+	return b == ' ' || b == '\t'
+}
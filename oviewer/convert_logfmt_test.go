@@ -0,0 +1,69 @@
+package oviewer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_parseLogfmt(t *testing.T) {
+	type args struct {
+		str string
+	}
+	tests := []struct {
+		name string
+		args args
+		want []field
+	}{
+		{
+			name: "simple",
+			args: args{
+				str: "level=info dur=12ms",
+			},
+			want: []field{
+				{key: "level", start: 6, end: 10},
+				{key: "dur", start: 15, end: 19},
+			},
+		},
+		{
+			name: "quoted",
+			args: args{
+				str: `msg="hello \"world\"" ok`,
+			},
+			want: []field{
+				{key: "msg", start: 5, end: 20},
+				{key: "ok", start: 24, end: 24},
+			},
+		},
+		{
+			name: "empty",
+			args: args{
+				str: "a= b=1",
+			},
+			want: []field{
+				{key: "a", start: 2, end: 2},
+				{key: "b", start: 5, end: 6},
+			},
+		},
+		{
+			name: "notLogfmt",
+			args: args{
+				str: "hello world",
+			},
+			want: nil,
+		},
+		{
+			name: "quotedText",
+			args: args{
+				str: `"a=b"`,
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseLogfmt(tt.args.str); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseLogfmt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
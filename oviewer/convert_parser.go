@@ -0,0 +1,113 @@
+package oviewer
+
+import (
+	"regexp"
+	"sort"
+)
+
+// builtinParsers is a list of predefined parsers.
+// Each named group of the regular expression becomes a column.
+var builtinParsers = map[string]string{
+	// combined is the combined log format of nginx and Apache (the common log format is also matched).
+	"combined": `^(?P<remote>\S+) (?P<ident>\S+) (?P<user>\S+) \[(?P<time>[^\]]+)\] "(?P<request>(?:[^"\\]|\\.)*)" (?P<status>\d{3}) (?P<size>\S+)(?: "(?P<referer>(?:[^"\\]|\\.)*)" "(?P<agent>(?:[^"\\]|\\.)*)")?`,
+	// rfc3164 is the BSD syslog format.
+	"rfc3164": `^(?:<(?P<pri>\d{1,3})>)?(?P<time>[A-Z][a-z]{2} [ \d]\d \d{2}:\d{2}:\d{2}) (?P<host>\S+) (?P<tag>[^:\[\s]+)(?:\[(?P<pid>\d+)\])?: ?(?P<message>.*)$`,
+	// rfc5424 is the syslog protocol format.
+	"rfc5424": `^<(?P<pri>\d{1,3})>(?P<version>\d{1,2}) (?P<time>\S+) (?P<host>\S+) (?P<app>\S+) (?P<procid>\S+) (?P<msgid>\S+) (?P<sd>-|(?:\[(?:[^\]\\]|\\.)*\])+) ?(?P<message>.*)$`,
+	// k8s is the CRI log format of Kubernetes containers (/var/log/containers/*.log).
+	"k8s": `^(?P<time>\S+) (?P<stream>stdout|stderr) (?P<tag>[FP]) (?P<message>.*)$`,
+}
+
+// builtinModes returns the view modes of the predefined parsers.
+// The view modes in the configuration file take precedence.
+func builtinModes() map[string]General {
+	modes := make(map[string]General, len(builtinParsers))
+	for name := range builtinParsers {
+		g := General{}
+		g.SetConverter(convParser)
+		g.SetParser(name)
+		g.SetColumnMode(true)
+		modes[name] = g
+	}
+	return modes
+}
+
+// lookupViewMode returns the view mode of the name from the configuration file or the predefined parsers.
+func lookupViewMode(config Config, name string) (General, bool) {
+	if viewMode, ok := config.Mode[name]; ok {
+		return viewMode, true
+	}
+	viewMode, ok := builtinModes()[name]
+	return viewMode, ok
+}
+
+// builtinParserNames returns the sorted names of the predefined parsers.
+func builtinParserNames() []string {
+	names := make([]string, 0, len(builtinParsers))
+	for name := range builtinParsers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// parserRegexp compiles the parser.
+// parser is the name of the predefined parser or a regular expression with named groups.
+func parserRegexp(parser string) (*regexp.Regexp, error) {
+	if pattern, ok := builtinParsers[parser]; ok {
+		parser = pattern
+	}
+	re, err := regexp.Compile(parser)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range re.SubexpNames() {
+		if name != "" {
+			return re, nil
+		}
+	}
+	return nil, ErrNoNamedGroup
+}
+
+// newParserConverter returns a converter that converts the named groups of the regular expression into columns.
+// All named groups are columns in the order of the regular expression,
+// even if the line does not match.
+func newParserConverter(re *regexp.Regexp) *fieldConverter {
+	conv := newFieldConverter(func(str string) []field {
+		return parseRegexp(re, str)
+	})
+	if re == nil {
+		return conv
+	}
+	for _, name := range re.SubexpNames() {
+		if name != "" {
+			conv.addKey(name)
+		}
+	}
+	return conv
+}
+
+// parseRegexp returns the named groups of the regular expression as fields.
+// Returns nil if the line does not match.
+func parseRegexp(re *regexp.Regexp, str string) []field {
+	if re == nil {
+		return nil
+	}
+	match := re.FindStringSubmatchIndex(str)
+	if match == nil {
+		return nil
+	}
+	names := re.SubexpNames()
+	fields := make([]field, 0, len(names))
+	for i, name := range names {
+		if i == 0 || name == "" {
+			continue
+		}
+		start, end := match[i*2], match[i*2+1]
+		if start < 0 {
+			start, end = 0, 0
+		}
+		fields = append(fields, field{key: name, start: start, end: end})
+	}
+	return fields
+}
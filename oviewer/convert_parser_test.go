@@ -0,0 +1,182 @@
+package oviewer
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func Test_parserRegexp(t *testing.T) {
+	tests := []struct {
+		name    string
+		parser  string
+		wantErr error
+	}{
+		{
+			name:    "builtin",
+			parser:  "combined",
+			wantErr: nil,
+		},
+		{
+			name:    "namedGroup",
+			parser:  `^(?P<level>\w+) (?P<msg>.*)$`,
+			wantErr: nil,
+		},
+		{
+			name:    "noNamedGroup",
+			parser:  `^(\w+) (.*)$`,
+			wantErr: ErrNoNamedGroup,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parserRegexp(tt.parser)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("parserRegexp() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+	if _, err := parserRegexp("(?P<a>"); err == nil {
+		t.Errorf("parserRegexp() error = nil, want compile error")
+	}
+}
+
+func Test_builtinParsers(t *testing.T) {
+	tests := []struct {
+		name   string
+		parser string
+		str    string
+		want   map[string]string
+	}{
+		{
+			name:   "combined",
+			parser: "combined",
+			str:    `127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 2326 "http://www.example.com/start.html" "Mozilla/4.08"`,
+			want: map[string]string{
+				"remote":  "127.0.0.1",
+				"user":    "frank",
+				"time":    "10/Oct/2000:13:55:36 -0700",
+				"request": "GET /apache_pb.gif HTTP/1.0",
+				"status":  "200",
+				"size":    "2326",
+				"referer": "http://www.example.com/start.html",
+				"agent":   "Mozilla/4.08",
+			},
+		},
+		{
+			name:   "common",
+			parser: "combined",
+			str:    `127.0.0.1 - - [10/Oct/2000:13:55:36 -0700] "GET / HTTP/1.0" 404 -`,
+			want: map[string]string{
+				"status":  "404",
+				"size":    "-",
+				"referer": "",
+			},
+		},
+		{
+			name:   "rfc3164",
+			parser: "rfc3164",
+			str:    `<34>Oct 11 22:14:15 mymachine su[123]: 'su root' failed for lonvick on /dev/pts/8`,
+			want: map[string]string{
+				"pri":     "34",
+				"time":    "Oct 11 22:14:15",
+				"host":    "mymachine",
+				"tag":     "su",
+				"pid":     "123",
+				"message": "'su root' failed for lonvick on /dev/pts/8",
+			},
+		},
+		{
+			name:   "rfc5424",
+			parser: "rfc5424",
+			str:    `<165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog - ID47 [exampleSDID@32473 iut="3"] An application event`,
+			want: map[string]string{
+				"pri":     "165",
+				"version": "1",
+				"time":    "2003-10-11T22:14:15.003Z",
+				"host":    "mymachine.example.com",
+				"app":     "evntslog",
+				"procid":  "-",
+				"msgid":   "ID47",
+				"sd":      `[exampleSDID@32473 iut="3"]`,
+				"message": "An application event",
+			},
+		},
+		{
+			name:   "k8s",
+			parser: "k8s",
+			str:    `2024-01-01T00:00:00.000000000Z stderr F error: connection refused`,
+			want: map[string]string{
+				"time":    "2024-01-01T00:00:00.000000000Z",
+				"stream":  "stderr",
+				"tag":     "F",
+				"message": "error: connection refused",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			re, err := parserRegexp(tt.parser)
+			if err != nil {
+				t.Fatal(err)
+			}
+			fields := parseRegexp(re, tt.str)
+			if fields == nil {
+				t.Fatalf("parseRegexp() = nil")
+			}
+			got := make(map[string]string)
+			for _, f := range fields {
+				got[f.key] = tt.str[f.start:f.end]
+			}
+			for key, want := range tt.want {
+				if got[key] != want {
+					t.Errorf("parseRegexp() %s = %q, want %q", key, got[key], want)
+				}
+			}
+		})
+	}
+}
+
+func Test_newParserConverter(t *testing.T) {
+	re, err := parserRegexp(`^(?P<level>\w+) (?P<msg>.*)$`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conv := newParserConverter(re)
+	if !reflect.DeepEqual(conv.keys, []string{"level", "msg"}) {
+		t.Errorf("newParserConverter() keys = %v", conv.keys)
+	}
+	conv.maxWidths = []int{5, 3}
+	tests := []struct {
+		str  string
+		want string
+	}{
+		{str: "info start", want: "info │start"},
+		{str: "warn stop", want: "warn │stop"},
+		{str: "-", want: "-"},
+	}
+	for _, tt := range tests {
+		got, _ := ContentsToStr(parseString(conv, tt.str, 8))
+		if got != tt.want {
+			t.Errorf("parserConverter.convert() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func Test_lookupViewMode(t *testing.T) {
+	config := Config{
+		Mode: map[string]General{
+			"k8s": {},
+		},
+	}
+	if viewMode, ok := lookupViewMode(config, "k8s"); !ok || viewMode.Converter != nil {
+		t.Errorf("lookupViewMode() should prefer the configuration file")
+	}
+	viewMode, ok := lookupViewMode(config, "combined")
+	if !ok || viewMode.Parser == nil || *viewMode.Parser != "combined" {
+		t.Errorf("lookupViewMode() should return the predefined parser")
+	}
+	if _, ok := lookupViewMode(config, "unknown"); ok {
+		t.Errorf("lookupViewMode() should return false")
+	}
+}
@@ -24,6 +24,14 @@ func (root *Root) getDocument(docNum int) *Document {
 	return root.DocList[docNum]
 }
 
+// documentIndex returns the number of the document in the list.
+// Returns -1 if the document is not in the list.
+func (root *Root) documentIndex(m *Document) int {
+	root.mu.RLock()
+	defer root.mu.RUnlock()
+	return slices.Index(root.DocList, m)
+}
+
 // hasDocChanged checks if any document in the list has changed.
 func (root *Root) hasDocChanged() bool {
 	root.mu.RLock()
@@ -8,7 +8,6 @@ import (
 	"io/fs"
 	"log"
 	"os"
-	"regexp"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -26,9 +25,10 @@ const (
 	DocHelp
 	DocLog
 	DocFilter
+	DocOccur
 )
 
-// documentType represents the type of document (e.g., normal, help, log, filter).
+// documentType represents the type of document (e.g., normal, help, log, filter, occur).
 type documentType int
 
 // String returns the string representation of the document type.
@@ -42,6 +42,8 @@ func (d documentType) String() string {
 		return "log"
 	case DocFilter:
 		return "filter"
+	case DocOccur:
+		return "occur"
 	}
 	return "unknown"
 }
@@ -61,6 +63,8 @@ type Document struct {
 	parent *Document
 	// lineNumMap maps line numbers.
 	lineNumMap *biomap.Map[int, int]
+	// occurDocMap maps line numbers to the documents of the lines (occur document of all documents).
+	occurDocMap sync.Map
 
 	// ticker is used for periodic updates.
 	ticker *time.Ticker
@@ -69,8 +73,10 @@ type Document struct {
 	// ctlCh is the channel for controlling the reader goroutine.
 	ctlCh chan controlSpecifier
 
-	// multiColorRegexps holds multicolor regular expressions in slices.
-	multiColorRegexps []*regexp.Regexp
+	// sectionSearcher is the searcher of the section delimiter with the backtracking engine.
+	sectionSearcher Searcher
+	// multiColor finds the positions of the multicolor words.
+	multiColor *multiColorMatcher
 	// store represents store management.
 	store *store
 	// followStore represents follow store management.
@@ -80,6 +86,10 @@ type Document struct {
 	conv Converter
 	// alignConv is an interface that converts alignment.
 	alignConv *align
+	// logfmtConv is an interface that converts logfmt into columns.
+	logfmtConv *fieldConverter
+	// parserConv is an interface that converts the named groups of the parser into columns.
+	parserConv *fieldConverter
 
 	// cond is a condition variable for synchronization.
 	cond *sync.Cond
@@ -142,6 +152,12 @@ type Document struct {
 
 	// lastSearchLN is the last search line number.
 	lastSearchLN int
+	// matchLN is the last line number of the search (not the search slot) used for the match count.
+	matchLN int
+	// matchCount is the number of lines that match the search.
+	matchCount matchCount
+	// searchIndex is the index to skip the chunks that do not match the search.
+	searchIndex searchIndex
 	// showGotoF displays the specified line if it is true.
 	showGotoF bool
 
@@ -262,11 +278,14 @@ func NewDocument() (*Document, error) {
 		reopenable:      true,
 		store:           NewStore(),
 		lastSearchLN:    -1,
+		matchLN:         -1,
 	}
 	if err := m.NewCache(); err != nil {
 		return nil, err
 	}
 	m.alignConv = newAlignConverter(m.ColumnWidth)
+	m.logfmtConv = newLogfmtConverter()
+	m.parserConv = newParserConverter(nil)
 	m.conv = m.converterType(m.Converter)
 
 	m.cond = sync.NewCond(&sync.Mutex{})
@@ -299,10 +318,26 @@ func (m *Document) converterType(name string) Converter {
 		return newESConverter()
 	case convAlign:
 		return m.alignConv
+	case convLogfmt:
+		return m.logfmtConv
+	case convParser:
+		return m.parserConv
 	}
 	return defaultConverter
 }
 
+// fieldConv returns the field converter if the converter is logfmt or parser.
+// Otherwise, it returns nil.
+func (m *Document) fieldConv() *fieldConverter {
+	switch m.Converter {
+	case convLogfmt:
+		return m.logfmtConv
+	case convParser:
+		return m.parserConv
+	}
+	return nil
+}
+
 // OpenDocument opens a file specified by fileName and returns a Document.
 // If the fileName is "-", it reads from stdin. It returns an error if the file
 // cannot be opened, is a directory, or if there are issues initializing the Document.
@@ -617,6 +652,22 @@ func (m *Document) regexpCompile() {
 	if len(m.MultiColorWords) > 0 {
 		m.setMultiColorWords(m.MultiColorWords)
 	}
+	m.setParser(m.Parser)
+}
+
+// setParser sets the parser of the parser converter.
+// parser is the name of the predefined parser or a regular expression with named groups.
+func (m *Document) setParser(parser string) {
+	m.Parser = parser
+	if parser == "" {
+		m.parserConv = newParserConverter(nil)
+		return
+	}
+	re, err := parserRegexp(parser)
+	if err != nil {
+		log.Printf("parser %s: %s", parser, err)
+	}
+	m.parserConv = newParserConverter(re)
 }
 
 // setDelimiter sets the delimiter string.
@@ -629,16 +680,20 @@ func (m *Document) setDelimiter(delm string) {
 func (m *Document) setSectionDelimiter(delm string) {
 	m.SectionDelimiter = delm
 	m.SectionDelimiterReg = regexpCompile(delm, true)
+	// nil uses the regexp package.
+	m.sectionSearcher = newRegexpSearcher(delm, m.RegexpEngine, true)
 }
 
 // setMultiColorWords set multiple strings to highlight with multiple colors.
 func (m *Document) setMultiColorWords(words []string) {
 	m.MultiColorWords = words
-	m.multiColorRegexps = multiRegexpCompile(words)
+	m.multiColor = newMultiColorMatcher(words, m.RegexpEngine)
 }
 
 // setColumnWidths sets the column widths.
-// Guess the width of the columns using the first 1000 lines (maximum) and the headers.
+// If there is a separator line (such as "----- ---") in the header lines or just below them,
+// the column widths are derived from it.
+// Otherwise, guess the width of the columns using the first 1000 lines (maximum) and the headers.
 func (m *Document) setColumnWidths() {
 	if m.BufEndNum() == 0 {
 		return
@@ -652,6 +707,10 @@ func (m *Document) setColumnWidths() {
 	for n, line := range lines {
 		buf[n] = string(line)
 	}
+	if widths, ok := separatorWidths(buf, m.Header); ok {
+		m.columnWidths = widths
+		return
+	}
 	// Stop guessing if valid row count is not reached.
 	if !m.BufEOF() && len(buf) < 20 {
 		return
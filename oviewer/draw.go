@@ -21,6 +21,7 @@ func (root *Root) draw(ctx context.Context) {
 	root.prepareDraw(ctx)
 
 	root.drawRuler()
+	root.drawFieldHeader()
 	// Body.
 	lX := m.topLX
 	lN := m.topLN + root.scr.headerEnd
@@ -191,6 +192,27 @@ func (root *Root) drawRuler() {
 	}
 }
 
+// drawFieldHeader draws the keys of the field converter (logfmt, parser) under the ruler.
+func (root *Root) drawFieldHeader() {
+	m := root.Doc
+	conv := m.fieldConv()
+	if conv == nil {
+		return
+	}
+
+	y := root.scr.rulerHeight
+	lX := m.x
+	if m.WrapMode {
+		lX = 0
+	}
+	root.blankLineNumber(y)
+	root.drawNoWrapLine(y, lX, 0, LineC{lc: conv.header(), valid: true})
+	root.applyStyleToLine(y, m.Style.Header)
+	if root.scr.headerEnd == root.scr.headerLN {
+		root.applyStyleToLine(y, m.Style.HeaderBorder)
+	}
+}
+
 // drawWrapLine wraps and draws the contents and returns the next drawing position.
 func (root *Root) drawLine(y int, lX int, lN int, lineC LineC) (int, int) {
 	if root.Doc.WrapMode {
@@ -65,7 +65,12 @@ func (root *Root) event(ctx context.Context, ev tcell.Event) bool {
 	case *eventNextBackSearch:
 		root.backSearch(ctx, ev.str, -1)
 	case *eventSearchMove:
+		root.moveSearchDocument(ctx, ev.m)
 		root.searchGo(ctx, ev.ln, ev.searcher)
+	case *eventProgress:
+		root.showProgress(ev.p, ev.msg)
+	case *eventBacktrackTimeout:
+		root.setMessageLog(ev.msg)
 	case *eventReachEOF:
 		// Quit if small doc and config allows
 		if root.quitCheck() {
@@ -73,6 +78,7 @@ func (root *Root) event(ctx context.Context, ev tcell.Event) bool {
 		}
 		root.Config.QuitSmall = false
 		root.notifyEOFReached(ev.m)
+		root.startSearchIndex(ctx, ev.m)
 
 	// Input confirmation action event.
 	case *eventConverter:
@@ -81,6 +87,8 @@ func (root *Root) event(ctx context.Context, ev tcell.Event) bool {
 		root.setDelimiter(ev.value)
 	case *eventGoto:
 		root.goLine(ev.value)
+	case *eventGotoTime:
+		root.goTime(ctx, ev.value)
 	case *eventHeaderColumn:
 		root.setHeaderColumn(ev.value)
 	case *eventHeader:
@@ -89,10 +97,16 @@ func (root *Root) event(ctx context.Context, ev tcell.Event) bool {
 		root.setJumpTarget(ev.value)
 	case *eventMultiColor:
 		root.setMultiColor(ev.value)
+	case *eventLogfmtKeys:
+		root.setLogfmtHideKeys(ev.value)
 	case *eventSaveBuffer:
 		root.saveBuffer(ev.value)
 	case *eventInputSearch:
 		root.firstSearch(ctx, ev.searchType)
+	case *eventFilterExpr:
+		root.filterExpr(ctx, ev.value)
+	case *eventSearchSlot:
+		root.setSearchSlot(ev.value)
 	case *eventSkipLines:
 		root.setSkipLines(ev.value)
 	case *eventTabWidth:
@@ -167,6 +181,8 @@ func (root *Root) everyUpdate(ctx context.Context) {
 	case root.Doc.FollowSection:
 		root.followSection(ctx)
 	}
+	// Index the lines added by follow or reload.
+	root.startSearchIndex(ctx, root.Doc)
 
 	if !root.skipDraw && root.Doc.height > 0 {
 		root.draw(ctx)
@@ -191,6 +207,9 @@ func (root *Root) keyEvent(ctx context.Context, ev *tcell.EventKey) {
 	root.setMessage("")
 	switch root.input.Event.Mode() {
 	case Normal:
+		if root.Doc.documentType == DocOccur && root.occurKeyConfig.Capture(ev) == nil {
+			return
+		}
 		root.keyCapture(ev)
 	default:
 		root.inputEvent(ctx, ev)
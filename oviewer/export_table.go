@@ -0,0 +1,501 @@
+package oviewer
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"unicode"
+)
+
+// The name of the table format that can be exported.
+const (
+	tableCSV      string = "csv"      // tableCSV is comma-separated values.
+	tableTSV      string = "tsv"      // tableTSV is tab-separated values.
+	tableMarkdown string = "markdown" // tableMarkdown is a Markdown table.
+	tableJSON     string = "json"     // tableJSON is a JSON array of objects keyed by the header.
+)
+
+// tableFormat returns the table format name.
+// "md" is an alias for "markdown".
+func tableFormat(format string) (string, error) {
+	switch strings.ToLower(format) {
+	case tableCSV:
+		return tableCSV, nil
+	case tableTSV:
+		return tableTSV, nil
+	case tableMarkdown, "md":
+		return tableMarkdown, nil
+	case tableJSON:
+		return tableJSON, nil
+	}
+	return "", fmt.Errorf("%w: %s", ErrInvalidTableFormat, format)
+}
+
+// splitTableFormat splits the input of the save prompt into the table format and the file name.
+// The table format is specified as a prefix such as "csv:file.csv".
+// If there is no table format, format is empty.
+func splitTableFormat(input string) (string, string) {
+	prefix, fileName, ok := strings.Cut(input, ":")
+	if !ok {
+		return "", input
+	}
+	format, err := tableFormat(prefix)
+	if err != nil {
+		return "", input
+	}
+	return format, strings.TrimSpace(fileName)
+}
+
+// ExportTable exports the columns of the document as a table in the specified format.
+// The format is one of csv, tsv, markdown(md) and json.
+// The column view settings (delimiter, column width, header, converter, shrunk columns)
+// are applied to the exported table.
+// The lines are read twice, first to find the layout of the table and then to write the rows,
+// so that the rows are not held in memory.
+func (m *Document) ExportTable(w io.Writer, format string) error {
+	format, err := tableFormat(format)
+	if err != nil {
+		return err
+	}
+	f := m.chunkFile()
+	if f != nil {
+		defer f.Close()
+	}
+	layout, err := m.tableLayout(f)
+	if err != nil {
+		return err
+	}
+	var tw tableWriter
+	switch format {
+	case tableCSV:
+		tw = newCSVTable(w, ',')
+	case tableTSV:
+		tw = newCSVTable(w, '\t')
+	case tableMarkdown:
+		tw = &markdownTable{w: w}
+	case tableJSON:
+		tw = &jsonTable{w: w}
+	}
+	if err := tw.writeHeader(layout); err != nil {
+		return err
+	}
+	if err := m.tableLines(f, func(str string) error {
+		return tw.writeRow(layout.row(m.tableCells(str)))
+	}); err != nil {
+		return err
+	}
+	return tw.close()
+}
+
+// ExportTable reads the files and writes them as tables in the specified format
+// without displaying the screen.
+// The settings of config (including the view mode) are applied to the documents.
+// If there is no file name, it reads from standard input.
+func ExportTable(w io.Writer, config Config, format string, fileNames ...string) error {
+	if _, err := tableFormat(format); err != nil {
+		return err
+	}
+	settings, ok := configSettings(NewRunTimeSettings(), config)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrInvalidModeName, config.ViewMode)
+	}
+	if len(fileNames) == 0 {
+		fileNames = []string{"-"}
+	}
+	for _, fileName := range fileNames {
+		doc, err := OpenDocument(fileName)
+		if err != nil {
+			return err
+		}
+		doc.RunTimeSettings = updateRunTimeSettings(settings, doc.General)
+		doc.regexpCompile()
+		doc.conv = doc.converterType(doc.Converter)
+		for !doc.BufEOF() {
+			doc.WaitEOF()
+		}
+		err = doc.ExportTable(w, format)
+		doc.requestClose()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tableLayout is the layout of the table found by reading all the lines.
+type tableLayout struct {
+	// header is the header of the document, nil if there is no header.
+	header []string
+	// width is the number of cells of the widest line.
+	width int
+	// padding is the number of cells to which the rows are padded.
+	// The rows of the field converter are padded to the number of the keys.
+	padding int
+	// trimFirst and trimLast are true if the first and last cells are empty in all lines.
+	// They are the outside of the table border such as "| a | b |".
+	trimFirst bool
+	trimLast  bool
+	// narrow is true if there is a line with less than two cells, in which case no cells are trimmed.
+	narrow bool
+}
+
+// tableLayout reads the header and the rows of the document and returns the layout of the table.
+// The header is the last header line that is not a separator line (such as "+----+----+").
+func (m *Document) tableLayout(f *os.File) (*tableLayout, error) {
+	if m.ColumnWidth && len(m.columnWidths) == 0 {
+		m.setColumnWidths()
+	}
+	layout := &tableLayout{trimFirst: true, trimLast: true}
+	for lN := m.firstLine() - 1; lN >= m.SkipLines; lN-- {
+		str, err := m.tableLine(lN)
+		if err != nil {
+			return nil, err
+		}
+		if isSeparatorLine(str) {
+			continue
+		}
+		layout.header = m.tableCells(str)
+		break
+	}
+	if err := m.tableLines(f, func(str string) error {
+		layout.add(m.tableCells(str))
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	// The keys of the field converter are the header.
+	// Keys found in later lines are added to the end, so the rows are padded.
+	if conv := m.fieldConv(); conv != nil {
+		layout.header = delimiterCells(conv.header(), fieldDelimiter, nil)
+		layout.padding = len(layout.header)
+	}
+	if layout.header != nil {
+		layout.add(layout.header)
+	}
+	return layout, nil
+}
+
+// add updates the layout with the cells of a line.
+func (l *tableLayout) add(cells []string) {
+	l.width = max(l.width, len(cells))
+	if len(cells) < 2 {
+		l.narrow = true
+		return
+	}
+	l.trimFirst = l.trimFirst && cells[0] == ""
+	l.trimLast = l.trimLast && cells[len(cells)-1] == ""
+}
+
+// row returns the cells padded and without the empty edge cells.
+func (l *tableLayout) row(cells []string) []string {
+	for len(cells) < l.padding {
+		cells = append(cells, "")
+	}
+	if l.narrow {
+		return cells
+	}
+	if l.trimLast && len(cells) > 0 {
+		cells = cells[:len(cells)-1]
+	}
+	if l.trimFirst && len(cells) > 0 {
+		cells = cells[1:]
+	}
+	return cells
+}
+
+// outputHeader returns the header to write, or nil if there is no header.
+func (l *tableLayout) outputHeader() []string {
+	if l.header == nil {
+		return nil
+	}
+	return l.row(l.header)
+}
+
+// columnNames returns the header.
+// If there is no header, the names are column1, column2, ...
+func (l *tableLayout) columnNames() []string {
+	if header := l.outputHeader(); header != nil {
+		return header
+	}
+	names := l.row(make([]string, l.width))
+	for i := range names {
+		names[i] = "column" + strconv.Itoa(i+1)
+	}
+	return names
+}
+
+// tableLines calls fn for each line of the table body, excluding the separator lines.
+// The chunks that are not in memory are read from the file without being loaded,
+// so that a document larger than the memory limit can be exported.
+func (m *Document) tableLines(f *os.File, fn func(str string) error) error {
+	start := m.firstLine()
+	endNum := m.BufEndNum()
+	for chunkNum := start / ChunkSize; chunkNum*ChunkSize < endNum; chunkNum++ {
+		var fnErr error
+		if err := m.chunkLines(f, chunkNum, func(n int, line []byte) bool {
+			lN := chunkNum*ChunkSize + n
+			if lN < start {
+				return true
+			}
+			if lN >= endNum {
+				return false
+			}
+			str := string(line)
+			if isSeparatorLine(str) {
+				return true
+			}
+			fnErr = fn(str)
+			return fnErr == nil
+		}); err != nil {
+			return err
+		}
+		if fnErr != nil {
+			return fnErr
+		}
+	}
+	return nil
+}
+
+// isSeparatorLine returns true if the line is a separator line of the table.
+func isSeparatorLine(str string) bool {
+	_, ok := separatorPositions(str)
+	return ok
+}
+
+// tableLine returns the line of the header, loading the chunk if it is not loaded.
+func (m *Document) tableLine(lN int) (string, error) {
+	chunkNum, cn := chunkLineNum(lN)
+	if !m.store.isLoadedChunk(chunkNum, m.seekable) && atomic.LoadInt32(&m.closed) == 0 {
+		m.requestLoadSync(chunkNum)
+	}
+	line, err := m.store.GetChunkLine(chunkNum, cn)
+	if err != nil {
+		return "", err
+	}
+	return string(line), nil
+}
+
+// tableCells returns the cells of the line as displayed in column mode.
+func (m *Document) tableCells(str string) []string {
+	conv := m.conv
+	// Alignment is not needed for the cells.
+	if m.Converter == convAlign {
+		conv = newESConverter()
+	}
+	lc := parseString(conv, str, m.TabWidth)
+	if m.fieldConv() != nil {
+		return delimiterCells(lc, fieldDelimiter, nil)
+	}
+	if m.ColumnWidth {
+		return m.shrinkCells(columnWidthCells(lc, m.columnWidths))
+	}
+	return m.shrinkCells(delimiterCells(lc, m.ColumnDelimiter, m.ColumnDelimiterReg))
+}
+
+// delimiterCells returns the cells of the contents separated by the delimiter.
+func delimiterCells(lc contents, delimiter string, delimiterReg *regexp.Regexp) []string {
+	lineStr, pos := ContentsToStr(lc)
+	ranges := delimiterRanges(LineC{lc: lc, str: lineStr, pos: pos}, delimiter, delimiterReg)
+	if len(ranges) == 0 {
+		return []string{cellString(lc)}
+	}
+	cells := make([]string, 0, len(ranges))
+	for _, r := range ranges {
+		cells = append(cells, cellString(lc[r.start:r.end]))
+	}
+	return cells
+}
+
+// columnWidthCells returns the cells of the contents separated by the column widths.
+func columnWidthCells(lc contents, widths []int) []string {
+	if len(widths) == 0 {
+		return []string{cellString(lc)}
+	}
+	cells := make([]string, 0, len(widths)+1)
+	start := 0
+	for c := range len(widths) + 1 {
+		end := min(findColumnEnd(lc, widths, c, start), len(lc))
+		if start > end {
+			break
+		}
+		cells = append(cells, cellString(lc[start:end]))
+		start = end + 1
+	}
+	return cells
+}
+
+// shrinkCells removes the shrunk columns in align mode.
+func (m *Document) shrinkCells(cells []string) []string {
+	if m.Converter != convAlign {
+		return cells
+	}
+	dst := make([]string, 0, len(cells))
+	for i, cell := range cells {
+		if m.alignConv.isShrink(i) {
+			continue
+		}
+		dst = append(dst, cell)
+	}
+	return dst
+}
+
+// cellString returns the trimmed string of the cell.
+func cellString(lc contents) string {
+	str, _ := ContentsToStr(lc)
+	return strings.TrimFunc(str, func(r rune) bool {
+		return unicode.IsSpace(r) || isColumnBorder(r)
+	})
+}
+
+// tableWriter writes a table row by row.
+type tableWriter interface {
+	// writeHeader writes the header of the table.
+	writeHeader(layout *tableLayout) error
+	// writeRow writes a row of the table.
+	writeRow(row []string) error
+	// close writes the end of the table.
+	close() error
+}
+
+// csvTable writes the table as CSV (or TSV) with quoting.
+type csvTable struct {
+	w *csv.Writer
+}
+
+func newCSVTable(w io.Writer, comma rune) *csvTable {
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+	return &csvTable{w: cw}
+}
+
+// writeHeader writes the header if the document has a header.
+func (t *csvTable) writeHeader(layout *tableLayout) error {
+	header := layout.outputHeader()
+	if header == nil {
+		return nil
+	}
+	return t.w.Write(header)
+}
+
+func (t *csvTable) writeRow(row []string) error {
+	return t.w.Write(row)
+}
+
+func (t *csvTable) close() error {
+	t.w.Flush()
+	return t.w.Error()
+}
+
+// markdownTable writes the table as a Markdown table.
+type markdownTable struct {
+	w   io.Writer
+	num int
+}
+
+func (t *markdownTable) writeHeader(layout *tableLayout) error {
+	header := layout.columnNames()
+	t.num = len(header)
+	if err := t.writeRow(header); err != nil {
+		return err
+	}
+	separator := make([]string, len(header))
+	for i := range separator {
+		separator[i] = "---"
+	}
+	return t.writeRow(separator)
+}
+
+// writeRow writes a row of the Markdown table with at least num cells.
+func (t *markdownTable) writeRow(row []string) error {
+	var b strings.Builder
+	b.WriteString("|")
+	for i := range max(t.num, len(row)) {
+		cell := ""
+		if i < len(row) {
+			cell = strings.ReplaceAll(row[i], "|", "\\|")
+		}
+		b.WriteString(" ")
+		b.WriteString(cell)
+		b.WriteString(" |")
+	}
+	b.WriteString("\n")
+	_, err := io.WriteString(t.w, b.String())
+	return err
+}
+
+func (t *markdownTable) close() error {
+	return nil
+}
+
+// jsonTable writes the table as a JSON array of objects keyed by the header.
+// The keys are in the order of the columns.
+type jsonTable struct {
+	w io.Writer
+	// keys are the unique keys of the columns.
+	keys []string
+	// used is the set of the keys.
+	used map[string]bool
+	rows int
+}
+
+func (t *jsonTable) writeHeader(layout *tableLayout) error {
+	t.used = make(map[string]bool)
+	for _, name := range layout.columnNames() {
+		t.addKey(name)
+	}
+	_, err := io.WriteString(t.w, "[")
+	return err
+}
+
+// addKey adds the key of the next column.
+// A duplicate name gets a suffix ("name_2", "name_3", ...) so that the object has no duplicate keys.
+func (t *jsonTable) addKey(name string) {
+	key := name
+	for n := 2; t.used[key]; n++ {
+		key = name + "_" + strconv.Itoa(n)
+	}
+	t.used[key] = true
+	t.keys = append(t.keys, key)
+}
+
+func (t *jsonTable) writeRow(row []string) error {
+	var b strings.Builder
+	if t.rows > 0 {
+		b.WriteString(",")
+	}
+	t.rows++
+	b.WriteString("\n  {")
+	for i, cell := range row {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		for len(t.keys) <= i {
+			t.addKey("column" + strconv.Itoa(len(t.keys)+1))
+		}
+		k, err := json.Marshal(t.keys[i])
+		if err != nil {
+			return err
+		}
+		v, err := json.Marshal(cell)
+		if err != nil {
+			return err
+		}
+		b.Write(k)
+		b.WriteString(": ")
+		b.Write(v)
+	}
+	b.WriteString("}")
+	_, err := io.WriteString(t.w, b.String())
+	return err
+}
+
+func (t *jsonTable) close() error {
+	_, err := io.WriteString(t.w, "\n]\n")
+	return err
+}
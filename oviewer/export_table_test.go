@@ -0,0 +1,271 @@
+package oviewer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_tableFormat(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		format  string
+		want    string
+		wantErr error
+	}{
+		{name: "csv", format: "csv", want: tableCSV},
+		{name: "TSV", format: "TSV", want: tableTSV},
+		{name: "md", format: "md", want: tableMarkdown},
+		{name: "json", format: "json", want: tableJSON},
+		{name: "invalid", format: "xml", want: "", wantErr: ErrInvalidTableFormat},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tableFormat(tt.format)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("tableFormat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("tableFormat() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_splitTableFormat(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		input        string
+		wantFormat   string
+		wantFileName string
+	}{
+		{name: "noFormat", input: "file.txt", wantFormat: "", wantFileName: "file.txt"},
+		{name: "csv", input: "csv:file.csv", wantFormat: tableCSV, wantFileName: "file.csv"},
+		{name: "markdown", input: "md: file.md", wantFormat: tableMarkdown, wantFileName: "file.md"},
+		{name: "drive", input: "c:file.txt", wantFormat: "", wantFileName: "c:file.txt"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			format, fileName := splitTableFormat(tt.input)
+			if format != tt.wantFormat || fileName != tt.wantFileName {
+				t.Errorf("splitTableFormat() = %v, %v, want %v, %v", format, fileName, tt.wantFormat, tt.wantFileName)
+			}
+		})
+	}
+}
+
+func Test_tableLayout(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		header     []string
+		rows       [][]string
+		wantHeader []string
+		wantNames  []string
+		wantRows   [][]string
+	}{
+		{
+			name:       "border",
+			header:     []string{"", "a", "b", ""},
+			rows:       [][]string{{"", "1", "2", ""}},
+			wantHeader: []string{"a", "b"},
+			wantNames:  []string{"a", "b"},
+			wantRows:   [][]string{{"1", "2"}},
+		},
+		{
+			name:       "emptyValue",
+			header:     []string{"", "a", "b"},
+			rows:       [][]string{{"1", "2", ""}},
+			wantHeader: []string{"", "a", "b"},
+			wantNames:  []string{"", "a", "b"},
+			wantRows:   [][]string{{"1", "2", ""}},
+		},
+		{
+			name:       "noHeader",
+			header:     nil,
+			rows:       [][]string{{"", "1", ""}, {"", "2", ""}},
+			wantHeader: nil,
+			wantNames:  []string{"column1"},
+			wantRows:   [][]string{{"1"}, {"2"}},
+		},
+		{
+			name:       "narrow",
+			header:     nil,
+			rows:       [][]string{{"", "1", ""}, {""}},
+			wantHeader: nil,
+			wantNames:  []string{"column1", "column2", "column3"},
+			wantRows:   [][]string{{"", "1", ""}, {""}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			layout := &tableLayout{header: tt.header, trimFirst: true, trimLast: true}
+			for _, row := range tt.rows {
+				layout.add(row)
+			}
+			if tt.header != nil {
+				layout.add(tt.header)
+			}
+			if got := layout.outputHeader(); !reflect.DeepEqual(got, tt.wantHeader) {
+				t.Errorf("tableLayout.outputHeader() = %v, want %v", got, tt.wantHeader)
+			}
+			if got := layout.columnNames(); !reflect.DeepEqual(got, tt.wantNames) {
+				t.Errorf("tableLayout.columnNames() = %v, want %v", got, tt.wantNames)
+			}
+			for i, row := range tt.rows {
+				if got := layout.row(row); !reflect.DeepEqual(got, tt.wantRows[i]) {
+					t.Errorf("tableLayout.row() = %v, want %v", got, tt.wantRows[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDocument_ExportTable(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		str      string
+		settings func(m *Document)
+		format   string
+		want     string
+		wantErr  error
+	}{
+		{
+			name: "csv",
+			str:  "a,b\n1,\"x\"\n",
+			settings: func(m *Document) {
+				m.ColumnDelimiter = ","
+				m.Header = 1
+			},
+			format: "csv",
+			want:   "a,b\n1,\"\"\"x\"\"\"\n",
+		},
+		{
+			name: "tsv",
+			str:  "a,b\n1,2\n",
+			settings: func(m *Document) {
+				m.ColumnDelimiter = ","
+			},
+			format: "tsv",
+			want:   "a\tb\n1\t2\n",
+		},
+		{
+			name: "markdown",
+			str:  "a,b\n1,x|y\n",
+			settings: func(m *Document) {
+				m.ColumnDelimiter = ","
+				m.Header = 1
+			},
+			format: "markdown",
+			want:   "| a | b |\n| --- | --- |\n| 1 | x\\|y |\n",
+		},
+		{
+			name: "jsonNoHeader",
+			str:  "b,a\n",
+			settings: func(m *Document) {
+				m.ColumnDelimiter = ","
+			},
+			format: "json",
+			want:   "[\n  {\"column1\": \"b\", \"column2\": \"a\"}\n]\n",
+		},
+		{
+			name: "columnWidth",
+			str:  "+----+-----+\n| id | name |\n+----+-----+\n| 1  | ab  |\n+----+-----+\n",
+			settings: func(m *Document) {
+				m.ColumnWidth = true
+				m.Header = 3
+			},
+			format: "json",
+			want:   "[\n  {\"id\": \"1\", \"name\": \"ab\"}\n]\n",
+		},
+		{
+			name: "logfmt",
+			str:  "level=info msg=start\nlevel=warn dur=1s\n",
+			settings: func(m *Document) {
+				m.Converter = convLogfmt
+				m.conv = m.converterType(m.Converter)
+			},
+			format: "csv",
+			want:   "level,msg,dur\ninfo,start,\nwarn,,1s\n",
+		},
+		{
+			name: "jsonDuplicateHeader",
+			str:  "name,name,name_2\na,b,c\n",
+			settings: func(m *Document) {
+				m.ColumnDelimiter = ","
+				m.Header = 1
+			},
+			format: "json",
+			want:   "[\n  {\"name\": \"a\", \"name_2\": \"b\", \"name_2_2\": \"c\"}\n]\n",
+		},
+		{
+			name:     "invalid",
+			str:      "a\n",
+			settings: func(m *Document) {},
+			format:   "xml",
+			want:     "",
+			wantErr:  ErrInvalidTableFormat,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := docHelper(t, tt.str)
+			tt.settings(m)
+			m.regexpCompile()
+			var buf bytes.Buffer
+			if err := m.ExportTable(&buf, tt.format); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Document.ExportTable() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("Document.ExportTable() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDocument_ExportTableUnloaded(t *testing.T) {
+	t.Parallel()
+	var b strings.Builder
+	var want strings.Builder
+	b.WriteString("id,name\n")
+	want.WriteString("id,name\n")
+	for n := range ChunkSize * 3 {
+		fmt.Fprintf(&b, "%d,n%d\n", n, n)
+		fmt.Fprintf(&want, "%d,n%d\n", n, n)
+	}
+	fileName := filepath.Join(t.TempDir(), "table.csv")
+	if err := os.WriteFile(fileName, []byte(b.String()), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	m := docFileReadHelper(t, fileName)
+	m.ColumnDelimiter = ","
+	m.Header = 1
+	m.regexpCompile()
+	m.store.unloadChunk(1)
+	m.store.unloadChunk(2)
+	var buf bytes.Buffer
+	if err := m.ExportTable(&buf, "csv"); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != want.String() {
+		t.Errorf("Document.ExportTable() = %d bytes, want %d bytes", len(got), want.Len())
+	}
+	// The chunks are read from the file without being loaded.
+	for _, chunkNum := range []int{1, 2} {
+		if m.store.isLoadedChunk(chunkNum, m.seekable) {
+			t.Errorf("chunk %d is loaded by the export", chunkNum)
+		}
+	}
+}
@@ -56,7 +56,11 @@ func (root *Root) filterDocument(ctx context.Context, searcher Searcher) {
 	if m.nonMatch {
 		match = "!" + match
 	}
+	sectionFilter := root.Config.SectionSearch && m.SectionDelimiter != ""
 	render.Caption = "filter:" + match
+	if sectionFilter {
+		render.Caption = "section filter:" + match
+	}
 	msg := "search:" + match
 	root.insertDocument(ctx, root.CurrentDoc, render)
 	render.RunTimeSettings = m.RunTimeSettings
@@ -77,13 +81,29 @@ func (root *Root) filterDocument(ctx context.Context, searcher Searcher) {
 		render.lineNumMap.Store(ln, ln)
 		writeLine(w, line)
 	}
-	go m.filterWriter(ctx, searcher, m.firstLine(), filterDoc)
+	go func() {
+		progress := &searchProgress{}
+		ctx := withProgress(ctx, progress)
+		stop := root.progressTicker(progress, msg)
+		timeouts := backtrackTimeouts(searcher)
+		if sectionFilter {
+			m.sectionFilterWriter(ctx, searcher, m.firstLine(), filterDoc)
+		} else {
+			m.filterWriter(ctx, searcher, m.firstLine(), filterDoc)
+		}
+		stop()
+		if note := backtrackTimeoutNote(searcher, timeouts); note != "" {
+			root.sendBacktrackTimeout(msg + note)
+		}
+	}()
 	root.setMessage(msg)
 }
 
 // filterWriter searches and writes to filterDoc.
 func (m *Document) filterWriter(ctx context.Context, searcher Searcher, startLN int, filterDoc *filterDocument) {
 	defer filterDoc.w.Close()
+	startChunk, _ := chunkLineNum(startLN)
+	defer m.startProgress(ctx, startChunk, 0, true)()
 	for originLN, renderLN := startLN, startLN; ; {
 		select {
 		case <-ctx.Done():
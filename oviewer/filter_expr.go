@@ -0,0 +1,505 @@
+package oviewer
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The filter expression filters lines by comparing the columns with values.
+//
+//	$5 > 1000
+//	status >= 500 AND method = GET
+//	duration between 1s and 5s
+//	size > 10MiB OR NOT error
+//
+// A column is specified by $number (starting from 1) or header name (or key of the field).
+// Values are compared as numbers, durations (1.5s, 200ms) or sizes (10KB, 1.5MiB, 2G),
+// and as strings otherwise.
+// A word that is not followed by an operator is searched in the whole line.
+
+// valueKind is the kind of the value to compare.
+type valueKind int
+
+const (
+	kindString valueKind = iota
+	kindNumber
+	kindDuration
+	kindSize
+)
+
+// predicate is a Searcher that compares the text (of a column) with the values.
+type predicate struct {
+	word string
+	op   string
+	kind valueKind
+	// values are the values to compare as numbers (two for between).
+	values []float64
+	// strs are the values to compare as strings (two for between).
+	strs []string
+	// caseSensitive is applied to the string comparison.
+	caseSensitive bool
+}
+
+// newPredicate returns the predicate that compares with the values (two for between).
+// The values are compared as strings if quoted, otherwise as the kind of the first value.
+func newPredicate(op string, values []string, quoted bool, caseSensitive bool) (predicate, error) {
+	pred := predicate{
+		op:            op,
+		kind:          kindString,
+		caseSensitive: caseSensitive,
+	}
+	if !quoted {
+		pred.kind = valueKindOf(values[0])
+	}
+	for _, v := range values {
+		if pred.kind == kindString {
+			if !caseSensitive {
+				v = strings.ToLower(v)
+			}
+			pred.strs = append(pred.strs, v)
+			continue
+		}
+		f, ok := parseValue(v, pred.kind)
+		if !ok {
+			return predicate{}, fmt.Errorf("%w: %q", ErrSearchExprSyntax, v)
+		}
+		pred.values = append(pred.values, f)
+	}
+	return pred, nil
+}
+
+// predicate Match compares the bytes.
+func (p predicate) Match(target []byte) bool {
+	return p.MatchString(string(target))
+}
+
+// predicate MatchString compares the string.
+func (p predicate) MatchString(target string) bool {
+	cell := strings.TrimSpace(stripEscapeSequenceString(target))
+	if p.kind == kindString {
+		return p.compareString(cell)
+	}
+	v, ok := parseValue(cell, p.kind)
+	if !ok {
+		return false
+	}
+	if p.op == "between" {
+		return p.values[0] <= v && v <= p.values[1]
+	}
+	return compareOp(p.op, compareFloat(v, p.values[0]))
+}
+
+// compareString compares the cell with the string value.
+func (p predicate) compareString(cell string) bool {
+	if !p.caseSensitive {
+		cell = strings.ToLower(cell)
+	}
+	if p.op == "between" {
+		return p.strs[0] <= cell && cell <= p.strs[1]
+	}
+	return compareOp(p.op, strings.Compare(cell, p.strs[0]))
+}
+
+// predicate FindAll returns the range of the trimmed text if it matches.
+func (p predicate) FindAll(target string) [][]int {
+	if !p.MatchString(target) {
+		return nil
+	}
+	start := len(target) - len(strings.TrimLeft(target, " "))
+	end := len(strings.TrimRight(target, " "))
+	if start >= end {
+		return nil
+	}
+	return [][]int{{start, end}}
+}
+
+// predicate String returns the predicate.
+func (p predicate) String() string {
+	return p.word
+}
+
+// compareFloat returns -1, 0 or 1 like strings.Compare.
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// compareOp reports whether the result of the comparison satisfies the operator.
+func compareOp(op string, c int) bool {
+	switch op {
+	case "=", "==":
+		return c == 0
+	case "!=", "<>":
+		return c != 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	}
+	return false
+}
+
+// sizeReg is a regular expression for the size (10KB, 1.5MiB, 2G).
+var sizeReg = regexp.MustCompile(`^(?i)([0-9]*\.?[0-9]+)\s*([kmgtp]?)(i?)(b?)$`)
+
+// parseSize parses the size and returns the number of bytes.
+// KB, MB... are powers of 1000, KiB, MiB... and K, M... (as in ls -h) are powers of 1024.
+func parseSize(str string) (float64, bool) {
+	m := sizeReg.FindStringSubmatch(str)
+	if m == nil || (m[2] == "" && m[4] == "") {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, false
+	}
+	exp := float64(strings.Index("kmgtp", strings.ToLower(m[2])) + 1)
+	if m[2] == "" {
+		exp = 0
+	}
+	base := 1024.0
+	if m[3] == "" && m[4] != "" {
+		base = 1000
+	}
+	return v * math.Pow(base, exp), true
+}
+
+// parseValue parses the string as the kind of value.
+func parseValue(str string, kind valueKind) (float64, bool) {
+	switch kind {
+	case kindNumber:
+		v, err := strconv.ParseFloat(str, 64)
+		return v, err == nil
+	case kindDuration:
+		d, err := time.ParseDuration(str)
+		return d.Seconds(), err == nil
+	case kindSize:
+		if v, ok := parseSize(str); ok {
+			return v, true
+		}
+		// A number without a unit is the number of bytes (such as the size column of ls -l).
+		v, err := strconv.ParseFloat(str, 64)
+		return v, err == nil
+	}
+	return 0, false
+}
+
+// valueKindOf returns the kind of the value literal.
+func valueKindOf(str string) valueKind {
+	for _, kind := range []valueKind{kindNumber, kindDuration, kindSize} {
+		if _, ok := parseValue(str, kind); ok {
+			return kind
+		}
+	}
+	return kindString
+}
+
+// filterTokenType is the type of the token of the filter expression.
+type filterTokenType int
+
+const (
+	filterWord filterTokenType = iota
+	filterString
+	filterOp
+	filterOpen
+	filterClose
+)
+
+// filterToken is a token of the filter expression.
+type filterToken struct {
+	typ filterTokenType
+	str string
+}
+
+// filterOps is the list of the comparison operators (longest first).
+var filterOps = []string{"<=", ">=", "==", "!=", "<>", "<", ">", "="}
+
+// tokenizeFilterExpr splits the filter expression into tokens.
+func tokenizeFilterExpr(expr string) ([]filterToken, error) {
+	var tokens []filterToken
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+			continue
+		case c == '(':
+			tokens = append(tokens, filterToken{typ: filterOpen, str: "("})
+			i++
+			continue
+		case c == ')':
+			tokens = append(tokens, filterToken{typ: filterClose, str: ")"})
+			i++
+			continue
+		case c == '"':
+			end := quoteEnd(expr, i)
+			if end < 0 {
+				return nil, ErrSearchExprUnclosed
+			}
+			s, err := strconv.Unquote(expr[i : end+1])
+			if err != nil {
+				s = expr[i+1 : end]
+			}
+			tokens = append(tokens, filterToken{typ: filterString, str: s})
+			i = end + 1
+			continue
+		}
+		if op := filterOpPrefix(expr[i:]); op != "" {
+			tokens = append(tokens, filterToken{typ: filterOp, str: op})
+			i += len(op)
+			continue
+		}
+		if c == '!' {
+			return nil, fmt.Errorf("%w: %q", ErrSearchExprSyntax, "!")
+		}
+		n := strings.IndexAny(expr[i:], " \t()\"<>=!")
+		if n < 0 {
+			n = len(expr) - i
+		}
+		tokens = append(tokens, filterToken{typ: filterWord, str: expr[i : i+n]})
+		i += n
+	}
+	return tokens, nil
+}
+
+// filterOpPrefix returns the operator at the beginning of s.
+func filterOpPrefix(s string) string {
+	for _, op := range filterOps {
+		if strings.HasPrefix(s, op) {
+			return op
+		}
+	}
+	return ""
+}
+
+// filterExprParser is a parser of the filter expression.
+type filterExprParser struct {
+	m      *Document
+	tokens []filterToken
+	pos    int
+	// caseSensitive is applied to the string comparison and the words.
+	caseSensitive bool
+	// regexpSearch interprets the words as regular expressions.
+	regexpSearch bool
+}
+
+// newFilterExpr returns the Searcher of the filter expression.
+func (m *Document) newFilterExpr(word string, caseSensitive bool, regexpSearch bool) (Searcher, error) {
+	tokens, err := tokenizeFilterExpr(word)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterExprParser{
+		m:             m,
+		tokens:        tokens,
+		caseSensitive: caseSensitive,
+		regexpSearch:  regexpSearch,
+	}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("%w: %q", ErrSearchExprSyntax, p.tokens[p.pos].str)
+	}
+	return searchExpr{
+		word:      word,
+		root:      node,
+		positives: node.positives(false),
+	}, nil
+}
+
+// peek returns the current token.
+func (p *filterExprParser) peek() (filterToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return filterToken{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+// isKeyword reports whether the current token is the keyword (case-insensitive).
+func (p *filterExprParser) isKeyword(keywords ...string) bool {
+	t, ok := p.peek()
+	if !ok || t.typ != filterWord {
+		return false
+	}
+	for _, k := range keywords {
+		if strings.EqualFold(t.str, k) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseOr parses: and { OR and }.
+func (p *filterExprParser) parseOr() (*exprNode, error) {
+	node, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	children := []*exprNode{node}
+	for p.isKeyword("or", "||") {
+		p.pos++
+		node, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, node)
+	}
+	if len(children) == 1 {
+		return children[0], nil
+	}
+	return &exprNode{op: exprOr, children: children}, nil
+}
+
+// parseAnd parses: unary { [AND] unary }.
+func (p *filterExprParser) parseAnd() (*exprNode, error) {
+	node, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	children := []*exprNode{node}
+	for {
+		t, ok := p.peek()
+		if !ok || t.typ == filterClose || p.isKeyword("or", "||") {
+			break
+		}
+		if p.isKeyword("and", "&&") {
+			p.pos++
+		}
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, node)
+	}
+	if len(children) == 1 {
+		return children[0], nil
+	}
+	return &exprNode{op: exprAnd, children: children}, nil
+}
+
+// parseUnary parses: NOT unary | ( or ) | predicate | word.
+func (p *filterExprParser) parseUnary() (*exprNode, error) {
+	if p.isKeyword("not") {
+		p.pos++
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &exprNode{op: exprNot, children: []*exprNode{node}}, nil
+	}
+	t, ok := p.peek()
+	if !ok {
+		return nil, ErrSearchExprSyntax
+	}
+	p.pos++
+	switch t.typ {
+	case filterOpen:
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if t, ok := p.peek(); !ok || t.typ != filterClose {
+			return nil, ErrSearchExprUnclosed
+		}
+		p.pos++
+		return node, nil
+	case filterWord, filterString:
+		if next, ok := p.peek(); ok && (next.typ == filterOp || p.isKeyword("between")) {
+			return p.parsePredicate(t)
+		}
+		word := t.str
+		searcher := NewSearcher(word, regexpCompile(word, p.caseSensitive), p.caseSensitive, p.regexpSearch && t.typ == filterWord)
+		return &exprNode{op: exprTerm, searcher: searcher}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrSearchExprSyntax, t.str)
+	}
+}
+
+// parsePredicate parses: column op value | column BETWEEN value AND value.
+func (p *filterExprParser) parsePredicate(column filterToken) (*exprNode, error) {
+	op := p.tokens[p.pos]
+	p.pos++
+	opStr := op.str
+	value, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+	values := []filterToken{value}
+	if op.typ != filterOp {
+		opStr = "between"
+		if !p.isKeyword("and", "&&") {
+			return nil, fmt.Errorf("%w: between requires and", ErrSearchExprSyntax)
+		}
+		p.pos++
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+
+	strs := make([]string, 0, len(values))
+	for _, v := range values {
+		strs = append(strs, v.str)
+	}
+	pred, err := newPredicate(opStr, strs, values[0].typ == filterString, p.caseSensitive)
+	if err != nil {
+		return nil, err
+	}
+	pred.word = column.str + " " + pred.op + " " + strings.Join(strs, " and ")
+
+	searcher, err := p.m.newColumnSearcher(pred, strings.TrimPrefix(column.str, "$"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, column.str)
+	}
+	return &exprNode{op: exprTerm, searcher: searcher}, nil
+}
+
+// value returns the value token.
+func (p *filterExprParser) value() (filterToken, error) {
+	t, ok := p.peek()
+	if !ok || (t.typ != filterWord && t.typ != filterString) {
+		return filterToken{}, fmt.Errorf("%w: value required", ErrSearchExprSyntax)
+	}
+	p.pos++
+	return t, nil
+}
+
+// filterExpr filters the document by the filter expression.
+func (root *Root) filterExpr(ctx context.Context, str string) {
+	if str == "" {
+		return
+	}
+	searcher, err := root.Doc.newFilterExpr(str, root.Config.CaseSensitive, root.Config.RegexpSearch)
+	if err != nil {
+		root.setMessageLogf("filter expression: %v", err)
+		return
+	}
+	root.searcher = searcher
+	root.Doc.nonMatch = false
+	root.filterDocument(ctx, searcher)
+}
+
+// FilterExpr filters the document by the filter expression.
+func (root *Root) FilterExpr(str string) {
+	go func() {
+		root.Doc.WaitEOFWithTimeout(root.Config.ReadWaitTime)
+		root.postEvent(&eventFilterExpr{value: str})
+	}()
+}
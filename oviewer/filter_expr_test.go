@@ -0,0 +1,229 @@
+package oviewer
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func Test_parseSize(t *testing.T) {
+	tests := []struct {
+		name   string
+		str    string
+		want   float64
+		wantOk bool
+	}{
+		{name: "byte", str: "100B", want: 100, wantOk: true},
+		{name: "kilo", str: "10KB", want: 10000, wantOk: true},
+		{name: "kibi", str: "10KiB", want: 10240, wantOk: true},
+		{name: "short", str: "2K", want: 2048, wantOk: true},
+		{name: "fraction", str: "1.5MiB", want: 1.5 * 1024 * 1024, wantOk: true},
+		{name: "lower", str: "1gb", want: 1000 * 1000 * 1000, wantOk: true},
+		{name: "space", str: "3 MB", want: 3 * 1000 * 1000, wantOk: true},
+		{name: "number", str: "100", want: 0, wantOk: false},
+		{name: "unknown", str: "10XB", want: 0, wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseSize(tt.str)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("parseSize() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func Test_valueKindOf(t *testing.T) {
+	tests := []struct {
+		name string
+		str  string
+		want valueKind
+	}{
+		{name: "number", str: "500", want: kindNumber},
+		{name: "float", str: "-1.5", want: kindNumber},
+		{name: "duration", str: "200ms", want: kindDuration},
+		{name: "minutes", str: "5m", want: kindDuration},
+		{name: "size", str: "10MiB", want: kindSize},
+		{name: "sizeUpper", str: "5M", want: kindSize},
+		{name: "string", str: "GET", want: kindString},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := valueKindOf(tt.str); got != tt.want {
+				t.Errorf("valueKindOf() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_tokenizeFilterExpr(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		want    []filterToken
+		wantErr bool
+	}{
+		{
+			name: "compare",
+			expr: "$3>=500",
+			want: []filterToken{
+				{typ: filterWord, str: "$3"},
+				{typ: filterOp, str: ">="},
+				{typ: filterWord, str: "500"},
+			},
+		},
+		{
+			name: "group",
+			expr: `(method != "GET POST")`,
+			want: []filterToken{
+				{typ: filterOpen, str: "("},
+				{typ: filterWord, str: "method"},
+				{typ: filterOp, str: "!="},
+				{typ: filterString, str: "GET POST"},
+				{typ: filterClose, str: ")"},
+			},
+		},
+		{
+			name:    "exclamation",
+			expr:    "!error",
+			wantErr: true,
+		},
+		{
+			name:    "unclosedQuote",
+			expr:    `method = "GET`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tokenizeFilterExpr(tt.expr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("tokenizeFilterExpr() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tokenizeFilterExpr() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDocument_newFilterExpr(t *testing.T) {
+	t.Parallel()
+	const csv = "id,method,status,latency,size\n" +
+		"1,GET,200,120ms,512\n" +
+		"2,POST,500,2.5s,10KB\n" +
+		"3,get,404,800ms,3MiB\n"
+	tests := []struct {
+		name   string
+		expr   string
+		target string
+		want   bool
+	}{
+		{name: "number", expr: "$3 >= 500", target: "2,POST,500,2.5s,10KB", want: true},
+		{name: "numberNotMatch", expr: "$3 >= 500", target: "1,GET,200,120ms,512", want: false},
+		{name: "headerName", expr: "status = 404", target: "3,get,404,800ms,3MiB", want: true},
+		{name: "notEqual", expr: "status <> 404", target: "3,get,404,800ms,3MiB", want: false},
+		{name: "string", expr: "method = get", target: "1,GET,200,120ms,512", want: true},
+		{name: "quotedString", expr: `method == "POST"`, target: "1,GET,200,120ms,512", want: false},
+		{name: "duration", expr: "latency between 500ms and 1s", target: "3,get,404,800ms,3MiB", want: true},
+		{name: "durationNotMatch", expr: "latency BETWEEN 500ms AND 1s", target: "2,POST,500,2.5s,10KB", want: false},
+		{name: "size", expr: "size > 1MB", target: "3,get,404,800ms,3MiB", want: true},
+		{name: "sizeNotMatch", expr: "size > 1MB", target: "2,POST,500,2.5s,10KB", want: false},
+		{name: "sizeBytes", expr: "size > 500B", target: "1,GET,200,120ms,512", want: true},
+		{name: "sizeBytesNotMatch", expr: "size > 1KB", target: "1,GET,200,120ms,512", want: false},
+		{name: "sizeBytesLarge", expr: "size > 1KB", target: "5,GET,200,10ms,2048", want: true},
+		{name: "notParsed", expr: "size > 1MB", target: "4,GET,200,10ms,unknown", want: false},
+		{name: "and", expr: "status >= 400 and method = post", target: "2,POST,500,2.5s,10KB", want: true},
+		{name: "or", expr: "status < 300 OR latency > 2s", target: "2,POST,500,2.5s,10KB", want: true},
+		{name: "not", expr: "NOT (status >= 400)", target: "1,GET,200,120ms,512", want: true},
+		{name: "word", expr: "status >= 400 POST", target: "3,get,404,800ms,3MiB", want: false},
+		{name: "escapeSequence", expr: "$3 > 400", target: "2,POST,\x1b[31m500\x1b[m,2.5s,10KB", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := columnSearchDocHelper(t, csv, 1)
+			searcher, err := m.newFilterExpr(tt.expr, false, false)
+			if err != nil {
+				t.Fatalf("newFilterExpr() error = %v", err)
+			}
+			if got := searcher.MatchString(tt.target); got != tt.want {
+				t.Errorf("MatchString() = %v, want %v", got, tt.want)
+			}
+			if got := searcher.Match([]byte(tt.target)); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDocument_newFilterExprError(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		expr    string
+		wantErr error
+	}{
+		{name: "noValue", expr: "status >=", wantErr: ErrSearchExprSyntax},
+		{name: "betweenWithoutAnd", expr: "latency between 1s 2s", wantErr: ErrSearchExprSyntax},
+		{name: "mixedKind", expr: "latency between 1s and 2KB", wantErr: ErrSearchExprSyntax},
+		{name: "unclosedParen", expr: "(status > 1", wantErr: ErrSearchExprUnclosed},
+		{name: "unknownColumn", expr: "unknown > 1", wantErr: ErrNoColumn},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := columnSearchDocHelper(t, "id,status,latency\n1,200,1s\n", 1)
+			_, err := m.newFilterExpr(tt.expr, false, false)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("newFilterExpr() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_predicate_FindAll(t *testing.T) {
+	p := predicate{op: ">", kind: kindNumber, values: []float64{100}}
+	if got, want := p.FindAll(" 500 "), [][]int{{1, 4}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FindAll() = %v, want %v", got, want)
+	}
+	if got := p.FindAll("50"); got != nil {
+		t.Errorf("FindAll() = %v, want nil", got)
+	}
+}
+
+func TestRoot_filterExpr(t *testing.T) {
+	tcellNewScreen = fakeScreen
+	defer func() {
+		tcellNewScreen = tcell.NewScreen
+	}()
+	root := rootFileReadHelper(t, filepath.Join(testdata, "MOCK_DATA.csv"))
+	root.Doc.ColumnDelimiter = ","
+	root.Doc.Header = 1
+	root.Doc.regexpCompile()
+
+	root.filterExpr(context.Background(), "id >")
+	if root.DocumentLen() != 1 {
+		t.Fatalf("filterExpr() with error = %v, want %v", root.DocumentLen(), 1)
+	}
+
+	root.filterExpr(context.Background(), "id between 2 and 3")
+	if root.DocumentLen() != 2 {
+		t.Fatalf("filterExpr() = %v, want %v", root.DocumentLen(), 2)
+	}
+	filterDoc := root.DocList[len(root.DocList)-1]
+	filterDoc.cond.L.Lock()
+	filterDoc.cond.Wait()
+	filterDoc.cond.L.Unlock()
+	for lN, want := range []string{"id,", "2,Yurik,", "3,Binky,"} {
+		line := filterDoc.getLineC(lN)
+		if !strings.HasPrefix(line.str, want) {
+			t.Errorf("filterExpr() line %d = %v, want prefix %v", lN, line.str, want)
+		}
+	}
+}
@@ -18,6 +18,16 @@ type General struct {
 	JumpTarget *string
 	// MultiColorWords specifies words to color separated by spaces.
 	MultiColorWords *[]string
+	// LogfmtHideKeys is the keys not to display in the logfmt converter.
+	LogfmtHideKeys *[]string
+	// Parser is the name of the predefined parser or a regular expression with named groups.
+	Parser *string
+	// SearchColumn is the column (number or header name) to which the search is limited.
+	SearchColumn *string
+	// RegexpEngine is the regular expression engine ("pcre" for the backtracking engine).
+	RegexpEngine *string
+	// TimeFormat is the format of the timestamp of the line used by goto time.
+	TimeFormat *string
 
 	// TabWidth is tab stop num.
 	TabWidth *int
@@ -58,6 +68,8 @@ type General struct {
 	LineNumMode *bool
 	// Wrap is Wrap mode.
 	WrapMode *bool
+	// TableWrap wraps long cells within their column width in align mode.
+	TableWrap *bool
 	// FollowMode is the follow mode.
 	FollowMode *bool
 	// FollowAll is a follow mode for all documents.
@@ -107,6 +119,33 @@ func (g *General) SetMultiColorWords(words []string) {
 	g.MultiColorWords = &copied
 }
 
+// SetLogfmtHideKeys sets the keys not to display in the logfmt converter.
+func (g *General) SetLogfmtHideKeys(keys []string) {
+	copied := make([]string, len(keys))
+	copy(copied, keys)
+	g.LogfmtHideKeys = &copied
+}
+
+// SetParser sets the parser of the parser converter.
+func (g *General) SetParser(parser string) {
+	g.Parser = &parser
+}
+
+// SetSearchColumn sets the column (number or header name) to which the search is limited.
+func (g *General) SetSearchColumn(column string) {
+	g.SearchColumn = &column
+}
+
+// SetRegexpEngine sets the regular expression engine.
+func (g *General) SetRegexpEngine(engine string) {
+	g.RegexpEngine = &engine
+}
+
+// SetTimeFormat sets the format of the timestamp of the line.
+func (g *General) SetTimeFormat(format string) {
+	g.TimeFormat = &format
+}
+
 // SetColumnMode sets the column mode.
 func (g *General) SetColumnMode(mode bool) {
 	g.ColumnMode = &mode
@@ -226,6 +265,11 @@ func (g *General) SetWrapMode(wrap bool) {
 	g.WrapMode = &wrap
 }
 
+// SetTableWrap sets the table wrap mode.
+func (g *General) SetTableWrap(tableWrap bool) {
+	g.TableWrap = &tableWrap
+}
+
 // SetFollowAll sets the follow mode for all documents.
 func (g *General) SetFollowAll(followAll bool) {
 	g.FollowAll = &followAll
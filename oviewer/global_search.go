@@ -0,0 +1,148 @@
+package oviewer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+)
+
+// globalSearchDocs returns the documents to be searched in global search, in the order of the search.
+// The search continues from the document after (before if backward) the current document,
+// wraps around, and ends with the current document.
+// Only normal documents are searched, and the filter and occur documents
+// are also searched if GlobalSearchFiltered is enabled.
+func (root *Root) globalSearchDocs(forward bool) []*Document {
+	root.mu.RLock()
+	defer root.mu.RUnlock()
+	num := len(root.DocList)
+	docs := make([]*Document, 0, num)
+	for i := 1; i <= num; i++ {
+		n := root.CurrentDoc + i
+		if !forward {
+			n = root.CurrentDoc - i
+		}
+		doc := root.DocList[((n%num)+num)%num]
+		if !root.isGlobalSearchDoc(doc) {
+			continue
+		}
+		docs = append(docs, doc)
+	}
+	return docs
+}
+
+// isGlobalSearchDoc returns true if the document is searched in global search.
+func (root *Root) isGlobalSearchDoc(doc *Document) bool {
+	switch doc.documentType {
+	case DocNormal:
+		return true
+	case DocFilter, DocOccur:
+		return root.Config.GlobalSearchFiltered
+	}
+	return false
+}
+
+// searchableDocs returns the documents searched in global search in the order of the document list.
+func (root *Root) searchableDocs() []*Document {
+	root.mu.RLock()
+	defer root.mu.RUnlock()
+	docs := make([]*Document, 0, len(root.DocList))
+	for _, doc := range root.DocList {
+		if root.isGlobalSearchDoc(doc) {
+			docs = append(docs, doc)
+		}
+	}
+	return docs
+}
+
+// globalSearchLine searches the other documents and returns the document and the line number.
+// Each document is searched from the beginning (from the end if backward).
+func (root *Root) globalSearchLine(ctx context.Context, searcher Searcher, forward bool) (*Document, int, error) {
+	for _, doc := range root.globalSearchDocs(forward) {
+		var n int
+		var err error
+		if forward {
+			n, err = doc.SearchLine(ctx, searcher, doc.firstLine())
+		} else {
+			n, err = doc.BackSearchLine(ctx, searcher, doc.BufEndNum()-1)
+		}
+		if err == nil {
+			return doc, n, nil
+		}
+		if errors.Is(err, ErrCancel) {
+			return nil, 0, err
+		}
+	}
+	return nil, 0, ErrNotFound
+}
+
+// toggleGlobalSearch toggles global search.
+func (root *Root) toggleGlobalSearch(context.Context) {
+	root.Config.GlobalSearch = !root.Config.GlobalSearch
+	root.setPromptOpt()
+}
+
+// moveSearchDocument switches to the document found by global search.
+func (root *Root) moveSearchDocument(ctx context.Context, m *Document) {
+	if m == nil || m == root.Doc {
+		return
+	}
+	docNum := root.documentIndex(m)
+	if docNum < 0 {
+		return
+	}
+	root.setDocumentNum(ctx, docNum)
+}
+
+// occurAll lists the lines that match the current search in all documents.
+func (root *Root) occurAll(ctx context.Context) {
+	if root.searcher == nil {
+		root.setMessage("no search word")
+		return
+	}
+	root.occurAllDocument(ctx, root.searcher)
+}
+
+// occurAllDocument creates a new document that lists the lines matching the searcher in all documents.
+// The documents are the same as global search (see isGlobalSearchDoc).
+// Each line is prefixed with the file name and the line number.
+func (root *Root) occurAllDocument(ctx context.Context, searcher Searcher) {
+	docs := root.searchableDocs()
+	if len(docs) == 0 {
+		root.setMessage("no document to search")
+		return
+	}
+
+	r, w := io.Pipe()
+	render, err := renderDoc(root.Doc, r)
+	if err != nil {
+		log.Printf("failed to occur document: %v\n", err)
+		return
+	}
+	render.documentType = DocOccur
+	render.Caption = "occur(all):" + searcher.String()
+	root.insertDocument(ctx, root.CurrentDoc, render)
+	render.RunTimeSettings = occurSettings(root.Doc.RunTimeSettings)
+	render.regexpCompile()
+	render.conv = render.converterType(render.Converter)
+	occurDoc := &occurDocument{
+		Document: render,
+		w:        w,
+		global:   true,
+	}
+	go func() {
+		defer occurDoc.w.Close()
+		renderLN := 0
+		for _, doc := range docs {
+			_, renderLN = doc.occurWrite(ctx, searcher, doc.firstLine(), renderLN, occurDoc)
+		}
+	}()
+	root.setMessagef("occur(all):%s", searcher.String())
+}
+
+// globalOccurLine returns the line prefixed with the file name and the line number.
+func globalOccurLine(line []byte, fileName string, number int) []byte {
+	prefix := fmt.Sprintf("%s:%d: ", fileName, number)
+	return append([]byte(prefix), line...)
+}
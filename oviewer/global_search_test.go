@@ -0,0 +1,300 @@
+package oviewer
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestRoot_globalSearchDocs(t *testing.T) {
+	tcellNewScreen = fakeScreen
+	defer func() {
+		tcellNewScreen = tcell.NewScreen
+	}()
+	type fields struct {
+		fileNames  []string
+		currentDoc int
+		filterDoc  int
+		filtered   bool
+	}
+	type args struct {
+		forward bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   []int
+	}{
+		{
+			name: "forward",
+			fields: fields{
+				fileNames: []string{
+					filepath.Join(testdata, "test.txt"),
+					filepath.Join(testdata, "test2.txt"),
+					filepath.Join(testdata, "test3.txt"),
+				},
+				currentDoc: 1,
+				filterDoc:  -1,
+			},
+			args: args{
+				forward: true,
+			},
+			want: []int{2, 0, 1},
+		},
+		{
+			name: "backward",
+			fields: fields{
+				fileNames: []string{
+					filepath.Join(testdata, "test.txt"),
+					filepath.Join(testdata, "test2.txt"),
+					filepath.Join(testdata, "test3.txt"),
+				},
+				currentDoc: 1,
+				filterDoc:  -1,
+			},
+			args: args{
+				forward: false,
+			},
+			want: []int{0, 2, 1},
+		},
+		{
+			name: "excludeFilter",
+			fields: fields{
+				fileNames: []string{
+					filepath.Join(testdata, "test.txt"),
+					filepath.Join(testdata, "test2.txt"),
+					filepath.Join(testdata, "test3.txt"),
+				},
+				currentDoc: 0,
+				filterDoc:  1,
+			},
+			args: args{
+				forward: true,
+			},
+			want: []int{2, 0},
+		},
+		{
+			name: "includeFilter",
+			fields: fields{
+				fileNames: []string{
+					filepath.Join(testdata, "test.txt"),
+					filepath.Join(testdata, "test2.txt"),
+					filepath.Join(testdata, "test3.txt"),
+				},
+				currentDoc: 0,
+				filterDoc:  1,
+				filtered:   true,
+			},
+			args: args{
+				forward: true,
+			},
+			want: []int{1, 2, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := rootFileReadHelper(t, tt.fields.fileNames...)
+			if tt.fields.filterDoc >= 0 {
+				root.DocList[tt.fields.filterDoc].documentType = DocFilter
+			}
+			root.CurrentDoc = tt.fields.currentDoc
+			root.Config.GlobalSearchFiltered = tt.fields.filtered
+			got := root.globalSearchDocs(tt.args.forward)
+			if len(got) != len(tt.want) {
+				t.Fatalf("globalSearchDocs() = %v documents, want %v", len(got), len(tt.want))
+			}
+			for i, doc := range got {
+				if doc != root.DocList[tt.want[i]] {
+					t.Errorf("globalSearchDocs()[%d] = %v, want %v", i, root.documentIndex(doc), tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRoot_globalSearchLine(t *testing.T) {
+	tcellNewScreen = fakeScreen
+	defer func() {
+		tcellNewScreen = tcell.NewScreen
+	}()
+	type fields struct {
+		currentDoc int
+	}
+	type args struct {
+		word    string
+		forward bool
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantDoc int
+		wantLN  int
+		wantErr error
+	}{
+		{
+			name: "forwardNext",
+			fields: fields{
+				currentDoc: 0,
+			},
+			args: args{
+				word:    "khaki",
+				forward: true,
+			},
+			wantDoc: 1,
+			wantLN:  0,
+		},
+		{
+			name: "forwardWrap",
+			fields: fields{
+				currentDoc: 1,
+			},
+			args: args{
+				word:    "12345",
+				forward: true,
+			},
+			wantDoc: 0,
+			wantLN:  12344,
+		},
+		{
+			name: "backward",
+			fields: fields{
+				currentDoc: 0,
+			},
+			args: args{
+				word:    "khaki",
+				forward: false,
+			},
+			wantDoc: 1,
+			wantLN:  35,
+		},
+		{
+			name: "notFound",
+			fields: fields{
+				currentDoc: 0,
+			},
+			args: args{
+				word:    "notfound",
+				forward: true,
+			},
+			wantErr: ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := rootFileReadHelper(t, filepath.Join(testdata, "test3.txt"), filepath.Join(testdata, "normal.txt"))
+			root.CurrentDoc = tt.fields.currentDoc
+			searcher := NewSearcher(tt.args.word, nil, false, false)
+			doc, lN, err := root.globalSearchLine(context.Background(), searcher, tt.args.forward)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("globalSearchLine() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("globalSearchLine() error = %v", err)
+			}
+			if doc != root.DocList[tt.wantDoc] {
+				t.Errorf("globalSearchLine() document = %v, want %v", root.documentIndex(doc), tt.wantDoc)
+			}
+			if lN != tt.wantLN {
+				t.Errorf("globalSearchLine() = %v, want %v", lN, tt.wantLN)
+			}
+		})
+	}
+}
+
+func TestRoot_occurAllDocument(t *testing.T) {
+	tcellNewScreen = fakeScreen
+	defer func() {
+		tcellNewScreen = tcell.NewScreen
+	}()
+	fileNames := []string{
+		filepath.Join(testdata, "test.txt"),
+		filepath.Join(testdata, "test2.txt"),
+	}
+	root := rootFileReadHelper(t, fileNames...)
+	docs := root.searchableDocs()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	root.occurAllDocument(ctx, NewSearcher("test", nil, false, false))
+	occurDoc := root.Doc
+	if occurDoc.documentType != DocOccur {
+		t.Fatalf("occurAllDocument() documentType = %v, want %v", occurDoc.documentType, DocOccur)
+	}
+	occurDoc.WaitEOF()
+	want := []string{
+		fileNames[0] + ":1: test",
+		fileNames[1] + ":1: test2",
+	}
+	for i, w := range want {
+		line := occurDoc.getLineC(i)
+		if line.str != w {
+			t.Errorf("occurAllDocument() line %d = %q, want %q", i, line.str, w)
+		}
+		doc, ok := occurDoc.occurDocMap.Load(i)
+		if !ok || doc.(*Document) != docs[i] {
+			t.Errorf("occurAllDocument() occurDocMap[%d] is not the document %d", i, i)
+		}
+	}
+}
+
+func TestRoot_searchableDocs(t *testing.T) {
+	tcellNewScreen = fakeScreen
+	defer func() {
+		tcellNewScreen = tcell.NewScreen
+	}()
+	root := rootFileReadHelper(t, filepath.Join(testdata, "test.txt"), filepath.Join(testdata, "test2.txt"))
+	root.DocList[1].documentType = DocFilter
+	if got := root.searchableDocs(); len(got) != 1 || got[0] != root.DocList[0] {
+		t.Errorf("searchableDocs() = %v documents, want the normal document", len(got))
+	}
+	root.Config.GlobalSearchFiltered = true
+	if got := root.searchableDocs(); len(got) != 2 || got[1] != root.DocList[1] {
+		t.Errorf("searchableDocs() = %v documents, want the filter document", len(got))
+	}
+}
+
+func Test_globalOccurLine(t *testing.T) {
+	type args struct {
+		line     string
+		fileName string
+		number   int
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "test",
+			args: args{
+				line:     "test",
+				fileName: "test.txt",
+				number:   1,
+			},
+			want: "test.txt:1: test",
+		},
+		{
+			name: "escape",
+			args: args{
+				line:     "\x1b[31mtest\x1b[m",
+				fileName: "a/b.log",
+				number:   123,
+			},
+			want: "a/b.log:123: \x1b[31mtest\x1b[m",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(globalOccurLine([]byte(tt.args.line), tt.args.fileName, tt.args.number)); got != tt.want {
+				t.Errorf("globalOccurLine() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,389 @@
+package oviewer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// timeDetector is a timestamp format that is detected automatically.
+type timeDetector struct {
+	// re matches the timestamp (the first group if any).
+	re    *regexp.Regexp
+	parse func(s string) (time.Time, error)
+}
+
+// timeDetectors is the timestamp formats detected automatically, in order of priority.
+var timeDetectors = []timeDetector{
+	// RFC3339 and similar formats such as "2006-01-02 15:04:05,000".
+	{
+		re:    regexp.MustCompile(`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}(?::\d{2}(?:[.,]\d+)?)?(?:Z|[+-]\d{2}:?\d{2})?`),
+		parse: parseISOTime,
+	},
+	// syslog (RFC3164) has no year, so the current year is used.
+	{
+		re:    regexp.MustCompile(`(?:Jan|Feb|Mar|Apr|May|Jun|Jul|Aug|Sep|Oct|Nov|Dec) [ \d]\d \d{2}:\d{2}:\d{2}`),
+		parse: parseSyslogTime,
+	},
+	// UNIX epoch in seconds, milliseconds (13 digits) or seconds with a fraction.
+	// It is only detected at the beginning of the line or as the value of a time key
+	// such as "ts" and "time", because other numbers such as IDs may have the same digits.
+	{
+		re:    regexp.MustCompile(`(?:^\s*|\b(?:ts|time|timestamp|epoch)"?\s*[:=]\s*"?)(1\d{9}(?:\d{3}|\.\d+)?)\b`),
+		parse: parseEpochTime,
+	},
+}
+
+// isoLayouts is the layouts tried in order by parseISOTime.
+// Fractional seconds are accepted without being in the layout.
+var isoLayouts = []string{
+	"2006-01-02T15:04:05Z07:00",
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+}
+
+// parseISOTime parses a timestamp similar to RFC3339.
+// A timestamp without a time zone is in local time.
+func parseISOTime(s string) (time.Time, error) {
+	s = strings.Replace(s, " ", "T", 1)
+	s = strings.Replace(s, ",", ".", 1)
+	var err error
+	for _, layout := range isoLayouts {
+		var t time.Time
+		t, err = time.ParseInLocation(layout, s, time.Local)
+		if err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+// parseSyslogTime parses a syslog timestamp in the current year.
+func parseSyslogTime(s string) (time.Time, error) {
+	t, err := time.ParseInLocation(time.Stamp, s, time.Local)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.AddDate(time.Now().Year(), 0, 0), nil
+}
+
+// parseEpochTime parses a UNIX epoch.
+func parseEpochTime(s string) (time.Time, error) {
+	if !strings.Contains(s, ".") && len(s) == 13 {
+		ms, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.UnixMilli(ms), nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	sec, frac := math.Modf(f)
+	return time.Unix(int64(sec), int64(frac*1e9)), nil
+}
+
+// timeParser parses the timestamp of a line.
+type timeParser struct {
+	// re extracts the timestamp from the line (the first group if any).
+	re *regexp.Regexp
+	// layout is the layout of time.Parse.
+	// If both re and layout are empty, the format is detected automatically.
+	layout string
+}
+
+// newTimeParser returns a timeParser for the format.
+// The format is a layout of time.Parse, or a regular expression enclosed in "/" that extracts the timestamp.
+// An empty format detects RFC3339, syslog and UNIX epoch automatically.
+func newTimeParser(format string) (*timeParser, error) {
+	if format == "" {
+		return &timeParser{}, nil
+	}
+	if len(format) > 2 && format[0] == '/' && format[len(format)-1] == '/' {
+		re, err := regexp.Compile(format[1 : len(format)-1])
+		if err != nil {
+			return nil, err
+		}
+		return &timeParser{re: re}, nil
+	}
+	return &timeParser{layout: format}, nil
+}
+
+// parse returns the first timestamp in the line.
+func (p *timeParser) parse(line string) (time.Time, bool) {
+	if p.re != nil {
+		match := p.re.FindStringSubmatch(line)
+		if match == nil {
+			return time.Time{}, false
+		}
+		s := match[0]
+		if len(match) > 1 {
+			s = match[1]
+		}
+		return detectTime(s)
+	}
+	if p.layout != "" {
+		return p.parseLayout(line)
+	}
+	return detectTime(line)
+}
+
+// parseLayout parses the text of the same length as the layout
+// at the beginning of the line or after a non-alphanumeric character.
+func (p *timeParser) parseLayout(line string) (time.Time, bool) {
+	width := len(p.layout)
+	for i := 0; i+width <= len(line); i++ {
+		if i > 0 && isAlnum(line[i-1]) {
+			continue
+		}
+		if t, err := time.ParseInLocation(p.layout, line[i:i+width], time.Local); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// isAlnum returns true if c is an ASCII letter or digit.
+func isAlnum(c byte) bool {
+	return ('0' <= c && c <= '9') || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+// detectTime returns the first timestamp in s of the automatically detected formats.
+func detectTime(s string) (time.Time, bool) {
+	for _, d := range timeDetectors {
+		match := d.re.FindStringSubmatch(s)
+		if match == nil {
+			continue
+		}
+		if t, err := d.parse(match[len(match)-1]); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// targetTime returns the time of the goto time input.
+// "-15m" is relative to the last timestamp (last), and "+1h" is relative to the first timestamp (first).
+// "d" can be used as a unit of days. A date only ("2006-01-02") is midnight of the day,
+// and a time only ("15:04" or "15:04:05") is on the day of the last timestamp.
+// Otherwise, the input is parsed as a timestamp of the line.
+func (p *timeParser) targetTime(input string, first time.Time, last time.Time) (time.Time, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return time.Time{}, ErrInvalidTime
+	}
+	if input[0] == '-' || input[0] == '+' {
+		d, err := parseRelativeDuration(input)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%w: %s", ErrInvalidTime, input)
+		}
+		if d < 0 {
+			return last.Add(d), nil
+		}
+		return first.Add(d), nil
+	}
+	if t, ok := p.parse(input); ok {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation(time.DateOnly, input, time.Local); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{time.TimeOnly, "15:04"} {
+		t, err := time.Parse(layout, input)
+		if err != nil {
+			continue
+		}
+		y, m, d := last.Date()
+		return time.Date(y, m, d, t.Hour(), t.Minute(), t.Second(), 0, last.Location()), nil
+	}
+	return time.Time{}, fmt.Errorf("%w: %s", ErrInvalidTime, input)
+}
+
+// parseRelativeDuration parses a signed duration such as "-15m" or "+1h30m".
+// In addition to the units of time.ParseDuration, "d" is accepted as 24 hours.
+func parseRelativeDuration(s string) (time.Duration, error) {
+	days, rest, ok := strings.Cut(s[1:], "d")
+	if !ok {
+		return time.ParseDuration(s)
+	}
+	n, err := strconv.Atoi(days)
+	if err != nil {
+		return 0, err
+	}
+	d := time.Duration(n) * 24 * time.Hour
+	if rest != "" {
+		r, err := time.ParseDuration(rest)
+		if err != nil {
+			return 0, err
+		}
+		d += r
+	}
+	if s[0] == '-' {
+		d = -d
+	}
+	return d, nil
+}
+
+// lineTime returns the timestamp of the line.
+func (p *timeParser) lineTime(line []byte) (time.Time, bool) {
+	return p.parse(stripEscapeSequenceString(string(line)))
+}
+
+// chunkFirstTime returns the first timestamp in the chunk.
+func (m *Document) chunkFirstTime(f *os.File, p *timeParser, chunkNum int) (time.Time, bool) {
+	var t time.Time
+	found := false
+	if err := m.chunkLines(f, chunkNum, func(_ int, line []byte) bool {
+		t, found = p.lineTime(line)
+		return !found
+	}); err != nil {
+		log.Printf("goto time: %v", err)
+	}
+	return t, found
+}
+
+// chunkLastTime returns the last timestamp in the chunk.
+func (m *Document) chunkLastTime(f *os.File, p *timeParser, chunkNum int) (time.Time, bool) {
+	var t time.Time
+	found := false
+	if err := m.chunkLines(f, chunkNum, func(_ int, line []byte) bool {
+		if lt, ok := p.lineTime(line); ok {
+			t, found = lt, true
+		}
+		return true
+	}); err != nil {
+		log.Printf("goto time: %v", err)
+	}
+	return t, found
+}
+
+// nextChunkTime returns the first timestamp from the chunk, skipping the chunks without a timestamp.
+// It returns false if there is no timestamp up to lastChunk or ctx is canceled.
+func (m *Document) nextChunkTime(ctx context.Context, f *os.File, p *timeParser, chunkNum int, lastChunk int) (time.Time, bool) {
+	for cn := chunkNum; cn <= lastChunk && ctx.Err() == nil; cn++ {
+		if t, ok := m.chunkFirstTime(f, p, cn); ok {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// timeRange returns the first and last timestamps of the document.
+func (m *Document) timeRange(ctx context.Context, f *os.File, p *timeParser, lastChunk int) (time.Time, time.Time, bool) {
+	first, ok := m.nextChunkTime(ctx, f, p, 0, lastChunk)
+	if !ok {
+		return first, time.Time{}, false
+	}
+	var last time.Time
+	ok = false
+	for chunkNum := lastChunk; chunkNum >= 0 && !ok && ctx.Err() == nil; chunkNum-- {
+		last, ok = m.chunkLastTime(f, p, chunkNum)
+	}
+	return first, last, ok
+}
+
+// searchTime returns the first line whose timestamp is at or after the input time, and the timestamp.
+// The lines must be in time order. The chunk is found by binary search with the first timestamp of each chunk,
+// and then the lines are searched from the previous chunk.
+// The lines without a timestamp are skipped.
+func (m *Document) searchTime(ctx context.Context, p *timeParser, input string) (int, time.Time, error) {
+	endNum := m.storeEndNum()
+	if endNum == 0 {
+		return 0, time.Time{}, ErrNoTimestamp
+	}
+	f := m.chunkFile()
+	if f != nil {
+		defer f.Close()
+	}
+	lastChunk, _ := chunkLineNum(endNum - 1)
+
+	first, last, ok := m.timeRange(ctx, f, p, lastChunk)
+	if ctx.Err() != nil {
+		return 0, time.Time{}, ErrCancel
+	}
+	if !ok {
+		return 0, time.Time{}, ErrNoTimestamp
+	}
+	target, err := p.targetTime(input, first, last)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	// A chunk without a timestamp has the timestamp of the next chunk with a timestamp,
+	// so that the chunks stay in time order.
+	// The chunks after the last timestamp are after the target.
+	chunkNum := sort.Search(lastChunk+1, func(cn int) bool {
+		t, ok := m.nextChunkTime(ctx, f, p, cn, lastChunk)
+		return !ok || !t.Before(target)
+	})
+	lN := -1
+	var found time.Time
+	for cn := max(chunkNum-1, 0); cn <= lastChunk && lN < 0; cn++ {
+		if ctx.Err() != nil {
+			return 0, target, ErrCancel
+		}
+		if err := m.chunkLines(f, cn, func(n int, line []byte) bool {
+			t, ok := p.lineTime(line)
+			if ok && !t.Before(target) {
+				lN = cn*ChunkSize + n
+				found = t
+				return false
+			}
+			return true
+		}); err != nil {
+			log.Printf("goto time: %v", err)
+		}
+	}
+	if lN < 0 {
+		return 0, target, fmt.Errorf("%w: after %s", ErrNotFound, target.Format(time.DateTime))
+	}
+	return lN, found, nil
+}
+
+// goTime moves to the first line whose timestamp is at or after the input time.
+// It searches in the background and can be canceled like the search.
+func (root *Root) goTime(ctx context.Context, input string) {
+	if len(input) == 0 {
+		return
+	}
+	p, err := newTimeParser(root.Doc.TimeFormat)
+	if err != nil {
+		root.setMessageLog(err.Error())
+		return
+	}
+	root.setMessage(fmt.Sprintf("goto time:%v (%v)Cancel", input, strings.Join(root.cancelKeys, ",")))
+	eg, ctx := errgroup.WithContext(ctx)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	eg.Go(func() error {
+		return root.cancelWait(cancel)
+	})
+
+	var lN int
+	var t time.Time
+	eg.Go(func() error {
+		var err error
+		lN, t, err = root.Doc.searchTime(ctx, p, input)
+		root.sendSearchQuit()
+		return err
+	})
+
+	if err := eg.Wait(); err != nil {
+		root.setMessage(err.Error())
+		return
+	}
+	lN = root.Doc.moveLine(lN - root.Doc.firstLine())
+	root.setMessagef("Moved to %s (line %d)", t.Format(time.DateTime), lN+1)
+}
@@ -0,0 +1,254 @@
+package oviewer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_timeParser_parse(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		format string
+		line   string
+		want   time.Time
+		wantOK bool
+	}{
+		{
+			name:   "rfc3339",
+			line:   "2024-01-02T15:04:05Z INFO start",
+			want:   time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC),
+			wantOK: true,
+		},
+		{
+			name:   "rfc3339Offset",
+			line:   "level=info time=2024-01-02T15:04:05.123+09:00",
+			want:   time.Date(2024, 1, 2, 6, 4, 5, 123000000, time.UTC),
+			wantOK: true,
+		},
+		{
+			name:   "spaceComma",
+			line:   "2024-01-02 15:04:05,500 INFO start",
+			want:   time.Date(2024, 1, 2, 15, 4, 5, 500000000, time.Local),
+			wantOK: true,
+		},
+		{
+			name:   "syslog",
+			line:   "Jan  2 15:04:05 host sshd[1]: start",
+			want:   time.Date(time.Now().Year(), 1, 2, 15, 4, 5, 0, time.Local),
+			wantOK: true,
+		},
+		{
+			name:   "epoch",
+			line:   `{"ts":1700000000,"msg":"start"}`,
+			want:   time.Unix(1700000000, 0),
+			wantOK: true,
+		},
+		{
+			name:   "epochMilli",
+			line:   "1700000000123 start",
+			want:   time.UnixMilli(1700000000123),
+			wantOK: true,
+		},
+		{
+			name:   "layout",
+			format: "02/Jan/2006:15:04:05 -0700",
+			line:   `127.0.0.1 - - [02/Jan/2024:15:04:05 +0000] "GET / HTTP/1.1"`,
+			want:   time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC),
+			wantOK: true,
+		},
+		{
+			name:   "regexp",
+			format: `/at (\d+)/`,
+			line:   "started at 1700000000",
+			want:   time.Unix(1700000000, 0),
+			wantOK: true,
+		},
+		{
+			name:   "epochLogfmt",
+			line:   "level=info time=1700000000.5 msg=start",
+			want:   time.Unix(1700000000, 500000000),
+			wantOK: true,
+		},
+		{
+			name:   "epochNotAnchored",
+			line:   "request id 1700000000 done",
+			wantOK: false,
+		},
+		{
+			name:   "none",
+			line:   "no timestamp",
+			wantOK: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			p, err := newTimeParser(tt.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, ok := p.parse(tt.line)
+			if ok != tt.wantOK {
+				t.Fatalf("parse() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_timeParser_targetTime(t *testing.T) {
+	t.Parallel()
+	first := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+	last := time.Date(2024, 1, 3, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "fromEnd", input: "-15m", want: time.Date(2024, 1, 3, 11, 45, 0, 0, time.UTC)},
+		{name: "fromStart", input: "+1h30m", want: time.Date(2024, 1, 2, 11, 30, 0, 0, time.UTC)},
+		{name: "days", input: "-1d2h", want: time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)},
+		{name: "timestamp", input: "2024-01-02T11:00:00Z", want: time.Date(2024, 1, 2, 11, 0, 0, 0, time.UTC)},
+		{name: "date", input: "2024-01-03", want: time.Date(2024, 1, 3, 0, 0, 0, 0, time.Local)},
+		{name: "timeOfDay", input: "09:30", want: time.Date(2024, 1, 3, 9, 30, 0, 0, time.UTC)},
+		{name: "invalidDuration", input: "-15x", wantErr: true},
+		{name: "invalid", input: "yesterday", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			p, err := newTimeParser("")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := p.targetTime(tt.input, first, last)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("targetTime() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidTime) {
+					t.Errorf("targetTime() error = %v, want %v", err, ErrInvalidTime)
+				}
+				return
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("targetTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDocument_searchTime(t *testing.T) {
+	start := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	var b strings.Builder
+	for n := range ChunkSize*3 + 10 {
+		if n%10 == 9 {
+			b.WriteString("  continued\n")
+			continue
+		}
+		fmt.Fprintf(&b, "%s line %d\n", start.Add(time.Duration(n)*time.Second).Format(time.RFC3339), n)
+	}
+	fileName := filepath.Join(t.TempDir(), "time.log")
+	if err := os.WriteFile(fileName, []byte(b.String()), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	m := docFileReadHelper(t, fileName)
+	// Search the chunks that are not in memory from the file.
+	m.store.unloadChunk(1)
+	m.store.unloadChunk(2)
+
+	p, err := newTimeParser("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		input   string
+		want    int
+		wantErr error
+	}{
+		{name: "exact", input: "2024-01-02T05:33:25Z", want: 20005},
+		{name: "between", input: "2024-01-02T02:46:48.5Z", want: 10010},
+		{name: "continued", input: "2024-01-02T00:00:09Z", want: 10},
+		{name: "fromEnd", input: "-9s", want: ChunkSize * 3},
+		{name: "beforeFirst", input: "2023-12-31", want: 0},
+		{name: "afterLast", input: "2024-01-03", wantErr: ErrNotFound},
+		{name: "invalid", input: "soon", wantErr: ErrInvalidTime},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := m.searchTime(context.Background(), p, tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("searchTime() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("searchTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDocument_searchTimeNoTimestamp(t *testing.T) {
+	m := docHelper(t, "a\nb\nc\n")
+	p, err := newTimeParser("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := m.searchTime(context.Background(), p, "-1m"); !errors.Is(err, ErrNoTimestamp) {
+		t.Errorf("searchTime() error = %v, want %v", err, ErrNoTimestamp)
+	}
+}
+
+func TestDocument_searchTimeSkipChunk(t *testing.T) {
+	start := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	var b strings.Builder
+	for n := range ChunkSize * 4 {
+		// The second chunk has no timestamp.
+		if n/ChunkSize == 1 {
+			b.WriteString("  continued\n")
+			continue
+		}
+		fmt.Fprintf(&b, "%s line %d\n", start.Add(time.Duration(n)*time.Second).Format(time.RFC3339), n)
+	}
+	m := docHelper(t, b.String())
+	p, err := newTimeParser("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name  string
+		input string
+		want  int
+	}{
+		{name: "beforeNoTimestamp", input: "2024-01-02T00:00:05Z", want: 5},
+		{name: "inNoTimestamp", input: "2024-01-02T02:56:40Z", want: ChunkSize * 2},
+		{name: "afterNoTimestamp", input: "2024-01-02T05:33:25Z", want: 20005},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := m.searchTime(context.Background(), p, tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("searchTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := m.searchTime(ctx, p, "-1m"); !errors.Is(err, ErrCancel) {
+		t.Errorf("searchTime() error = %v, want %v", err, ErrCancel)
+	}
+}
@@ -0,0 +1,290 @@
+package oviewer
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// defaultHistorySize is the maximum number of entries of each input history.
+const defaultHistorySize = 1000
+
+// historyNames is the file name of the history of each input mode.
+// The input modes not included here are not saved.
+var historyNames = map[InputMode]string{
+	Search:           "search",
+	Goline:           "goto",
+	Delimiter:        "delimiter",
+	TabWidth:         "tabwidth",
+	Watch:            "watch",
+	WriteBA:          "write_ba",
+	SectionDelimiter: "section_delimiter",
+	SectionStart:     "section_start",
+	MultiColor:       "multi_color",
+	JumpTarget:       "jump_target",
+	SaveBuffer:       "save_buffer",
+	FilterExpr:       "filter_expr",
+	SearchSlot:       "search_slot",
+	GotoTime:         "goto_time",
+}
+
+// history saves the input history to files for each input mode.
+// Entries are appended one line at a time, so that multiple ov can use the same files.
+type history struct {
+	dir  string
+	size int
+}
+
+// historyDir returns the directory of the history files ($XDG_STATE_HOME/ov).
+func historyDir() (string, error) {
+	dir := os.Getenv("XDG_STATE_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".local", "state")
+	}
+	return filepath.Join(dir, "ov"), nil
+}
+
+// newHistory returns the history with the configured size.
+func newHistory(size int) (*history, error) {
+	dir, err := historyDir()
+	if err != nil {
+		return nil, err
+	}
+	if size <= 0 {
+		size = defaultHistorySize
+	}
+	return &history{dir: dir, size: size}, nil
+}
+
+// historyMode returns the input mode that has the history of mode.
+// Backward search and filter share the history of search.
+func historyMode(mode InputMode) InputMode {
+	switch mode {
+	case Backsearch, Filter:
+		return Search
+	}
+	return mode
+}
+
+// path returns the file path of the history of the input mode.
+func (h *history) path(mode InputMode) (string, bool) {
+	name, ok := historyNames[historyMode(mode)]
+	if !ok {
+		return "", false
+	}
+	return filepath.Join(h.dir, name+"_history"), true
+}
+
+// load reads the histories into the candidates of input.
+func (h *history) load(input *Input) {
+	for mode := range historyNames {
+		c, ok := input.Candidate[mode]
+		if !ok {
+			continue
+		}
+		entries, err := h.read(mode)
+		if err != nil {
+			log.Printf("history: %v", err)
+			continue
+		}
+		c.mux.Lock()
+		for _, entry := range entries {
+			c.list = toLast(c.list, entry)
+		}
+		c.mux.Unlock()
+	}
+}
+
+// historyLockTimeout is the time to wait for the lock of the history file.
+const historyLockTimeout = time.Second
+
+// historyLockStale is the age of a lock file that is regarded as left by an ov that has exited.
+const historyLockStale = 10 * time.Second
+
+// read returns the entries of the history without duplicates, oldest first.
+// The file is compacted when it has many more lines than the entries.
+func (h *history) read(mode InputMode) ([]string, error) {
+	path, ok := h.path(mode)
+	if !ok {
+		return nil, nil
+	}
+	lines, err := readHistoryLines(path)
+	if err != nil {
+		return nil, err
+	}
+	entries := dedupHistory(lines, h.size)
+	if len(lines) > len(entries)*2 && len(lines) > h.size {
+		if err := h.compact(path); err != nil {
+			log.Printf("history: %v", err)
+		}
+	}
+	return entries, nil
+}
+
+// readHistoryLines returns all the entries of the history file.
+func readHistoryLines(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		entry, err := strconv.Unquote(scanner.Text())
+		if err != nil || entry == "" {
+			continue
+		}
+		lines = append(lines, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// dedupHistory returns the last size entries, keeping the last of duplicate entries.
+func dedupHistory(lines []string, size int) []string {
+	seen := make(map[string]bool, len(lines))
+	entries := make([]string, 0, min(len(lines), size))
+	for i := len(lines) - 1; i >= 0 && len(entries) < size; i-- {
+		if seen[lines[i]] {
+			continue
+		}
+		seen[lines[i]] = true
+		entries = append(entries, lines[i])
+	}
+	slices.Reverse(entries)
+	return entries
+}
+
+// lockHistory locks the history file by creating the lock file next to it.
+// The returned function unlocks it.
+// The lock is also taken by append, so that the entries appended by other ov
+// while compacting are not lost.
+func lockHistory(path string) (func(), error) {
+	lockPath := path + ".lock"
+	deadline := time.Now().Add(historyLockTimeout)
+	for {
+		f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			f.Close()
+			return func() { os.Remove(lockPath) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+		if info, err := os.Stat(lockPath); err == nil && time.Since(info.ModTime()) > historyLockStale {
+			os.Remove(lockPath)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("%w: %s", ErrHistoryLocked, lockPath)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// compact rewrites the history file without duplicate entries.
+// The file is re-read under the lock, so that the entries appended after read are kept.
+// The file is replaced by rename, so that other ov reading it do not see a partial file.
+func (h *history) compact(path string) error {
+	unlock, err := lockHistory(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	lines, err := readHistoryLines(path)
+	if err != nil {
+		return err
+	}
+	entries := dedupHistory(lines, h.size)
+
+	tmp, err := os.CreateTemp(h.dir, filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	w := bufio.NewWriter(tmp)
+	for _, entry := range entries {
+		if _, err := w.WriteString(strconv.Quote(entry) + "\n"); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// append adds the entry to the end of the history file.
+// The entry is written in one write with O_APPEND, so appends from other ov are not mixed.
+func (h *history) append(mode InputMode, entry string) error {
+	if entry == "" {
+		return nil
+	}
+	path, ok := h.path(mode)
+	if !ok {
+		return nil
+	}
+	if err := os.MkdirAll(h.dir, 0o700); err != nil {
+		return err
+	}
+	unlock, err := lockHistory(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(strconv.Quote(entry) + "\n"); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// loadHistory loads the input history unless it is disabled.
+func (root *Root) loadHistory() {
+	if root.Config.DisableHistory {
+		return
+	}
+	h, err := newHistory(root.Config.HistorySize)
+	if err != nil {
+		log.Printf("history: %v", err)
+		return
+	}
+	h.load(root.input)
+	root.history = h
+}
+
+// saveHistory appends the confirmed input to the history file.
+func (root *Root) saveHistory(mode InputMode, entry string) {
+	if root.history == nil {
+		return
+	}
+	if err := root.history.append(mode, entry); err != nil {
+		log.Printf("history: %v", err)
+	}
+}
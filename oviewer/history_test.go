@@ -0,0 +1,237 @@
+package oviewer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func Test_dedupHistory(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		size  int
+		want  []string
+	}{
+		{
+			name:  "noDuplicate",
+			lines: []string{"a", "b", "c"},
+			size:  10,
+			want:  []string{"a", "b", "c"},
+		},
+		{
+			name:  "keepLast",
+			lines: []string{"a", "b", "a", "c", "b"},
+			size:  10,
+			want:  []string{"a", "c", "b"},
+		},
+		{
+			name:  "size",
+			lines: []string{"a", "b", "c", "d"},
+			size:  2,
+			want:  []string{"c", "d"},
+		},
+		{
+			name:  "empty",
+			lines: nil,
+			size:  2,
+			want:  []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dedupHistory(tt.lines, tt.size); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("dedupHistory() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_historyDir(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", "/tmp/state")
+	got, err := historyDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join("/tmp/state", "ov"); got != want {
+		t.Errorf("historyDir() = %v, want %v", got, want)
+	}
+}
+
+func Test_history_appendRead(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	h, err := newHistory(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range []string{"error", "tab\tand\nnewline", "", "timeout", "error"} {
+		if err := h.append(Search, entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Backward search and filter share the history of search.
+	if err := h.append(Filter, "filter"); err != nil {
+		t.Fatal(err)
+	}
+	// Not saved.
+	if err := h.append(ViewMode, "markdown"); err != nil {
+		t.Fatal(err)
+	}
+	got, err := h.read(Backsearch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"timeout", "error", "filter"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("read() = %q, want %q", got, want)
+	}
+	if _, err := os.Stat(filepath.Join(h.dir, "view_mode_history")); !os.IsNotExist(err) {
+		t.Errorf("history of view mode is saved: %v", err)
+	}
+	info, err := os.Stat(filepath.Join(h.dir, "search_history"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("permission = %o, want %o", perm, 0o600)
+	}
+}
+
+func Test_history_compact(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	h, err := newHistory(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range 3 {
+		for _, entry := range []string{"a", "b", "c"} {
+			if err := h.append(Goline, entry); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if _, err := h.read(Goline); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(h.dir, "goto_history"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := strings.Split(strings.TrimSpace(string(data)), "\n"), []string{`"b"`, `"c"`}; !reflect.DeepEqual(got, want) {
+		t.Errorf("compacted file = %v, want %v", got, want)
+	}
+}
+
+func Test_history_concurrentWriters(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	const n = 100
+	var wg sync.WaitGroup
+	for w := range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Each writer is a different ov using the same file.
+			h, err := newHistory(0)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			path, _ := h.path(Goline)
+			for i := range n {
+				if err := h.append(Goline, fmt.Sprintf("%d-%d", w, i)); err != nil {
+					t.Error(err)
+					return
+				}
+				if err := h.compact(path); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	h, err := newHistory(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := h.read(Goline)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != n*2 {
+		t.Errorf("read() = %d entries, want %d", len(got), n*2)
+	}
+	if _, err := os.Stat(filepath.Join(h.dir, "goto_history.lock")); !os.IsNotExist(err) {
+		t.Errorf("lock file is left: %v", err)
+	}
+}
+
+func Test_lockHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "search_history")
+	unlock, err := lockHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := lockHistory(path); !errors.Is(err, ErrHistoryLocked) {
+		t.Errorf("lockHistory() error = %v, want %v", err, ErrHistoryLocked)
+	}
+	unlock()
+	// The lock left by an ov that has exited is removed.
+	if err := os.WriteFile(path+".lock", nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-historyLockStale * 2)
+	if err := os.Chtimes(path+".lock", old, old); err != nil {
+		t.Fatal(err)
+	}
+	unlock, err = lockHistory(path)
+	if err != nil {
+		t.Fatalf("lockHistory() error = %v", err)
+	}
+	unlock()
+}
+
+func TestRoot_loadHistory(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	h, err := newHistory(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := h.append(Delimiter, "|"); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.append(Delimiter, ":"); err != nil {
+		t.Fatal(err)
+	}
+
+	root := rootHelper(t)
+	root.Config.DisableHistory = true
+	root.loadHistory()
+	if root.history != nil {
+		t.Errorf("loadHistory() with DisableHistory = %v, want nil", root.history)
+	}
+
+	root.Config.DisableHistory = false
+	root.loadHistory()
+	if root.history == nil {
+		t.Fatal("loadHistory() = nil")
+	}
+	list := root.input.Candidate[Delimiter].list
+	if got := list[len(list)-2:]; !reflect.DeepEqual(got, []string{"|", ":"}) {
+		t.Errorf("loadHistory() = %v, want the history at the end", list)
+	}
+	root.saveHistory(Search, "saved")
+	entries, err := root.history.read(Search)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(entries, []string{"saved"}) {
+		t.Errorf("saveHistory() = %v, want %v", entries, []string{"saved"})
+	}
+}
@@ -55,6 +55,14 @@ const (
 	VerticalHeader
 	// HeaderColumn is for setting the number of vertical header columns.
 	HeaderColumn
+	// LogfmtKeys is for setting the keys to hide in the logfmt converter.
+	LogfmtKeys
+	// FilterExpr is for filtering by the filter expression.
+	FilterExpr
+	// SearchSlot is for setting the search slot.
+	SearchSlot
+	// GotoTime is for moving to a specific time.
+	GotoTime
 )
 
 // Input represents the status of various inputs.
@@ -89,6 +97,10 @@ func NewInput() *Input {
 	i.Candidate[JumpTarget] = jumpTargetCandidate()
 	i.Candidate[SaveBuffer] = blankCandidate()
 	i.Candidate[ConvertType] = converterCandidate()
+	i.Candidate[LogfmtKeys] = blankCandidate()
+	i.Candidate[FilterExpr] = blankCandidate()
+	i.Candidate[SearchSlot] = blankCandidate()
+	i.Candidate[GotoTime] = blankCandidate()
 
 	i.Event = &eventNormal{}
 	return &i
@@ -111,6 +123,7 @@ func (root *Root) inputEvent(ctx context.Context, ev *tcell.EventKey) {
 
 	// Fires a confirmed event.
 	input := root.input
+	root.saveHistory(input.Event.Mode(), input.value)
 	nev := input.Event.Confirm(input.value)
 	root.postEvent(nev)
 	input.Event = normal()
@@ -260,8 +273,52 @@ func (root *Root) toggleIncSearch(context.Context) {
 }
 
 // toggleRegexpSearch toggles regexp search.
+// Regular expression search and fuzzy search are exclusive.
 func (root *Root) toggleRegexpSearch(context.Context) {
 	root.Config.RegexpSearch = !root.Config.RegexpSearch
+	if root.Config.RegexpSearch {
+		root.Config.FuzzySearch = false
+	}
+	root.setPromptOpt()
+}
+
+// toggleFuzzySearch toggles fuzzy search.
+// Regular expression search and fuzzy search are exclusive.
+func (root *Root) toggleFuzzySearch(context.Context) {
+	root.Config.FuzzySearch = !root.Config.FuzzySearch
+	if root.Config.FuzzySearch {
+		root.Config.RegexpSearch = false
+	}
+	root.setPromptOpt()
+}
+
+// toggleExprSearch toggles search expression.
+func (root *Root) toggleExprSearch(context.Context) {
+	root.Config.ExprSearch = !root.Config.ExprSearch
+	root.setPromptOpt()
+}
+
+// toggleRawSearch toggles raw search.
+func (root *Root) toggleRawSearch(context.Context) {
+	root.Config.RawSearch = !root.Config.RawSearch
+	root.setPromptOpt()
+}
+
+// toggleWrapSearch toggles wrap-around search.
+func (root *Root) toggleWrapSearch(context.Context) {
+	root.Config.WrapSearch = !root.Config.WrapSearch
+	root.setPromptOpt()
+}
+
+// toggleSectionSearch toggles section search.
+func (root *Root) toggleSectionSearch(context.Context) {
+	root.Config.SectionSearch = !root.Config.SectionSearch
+	root.setPromptOpt()
+}
+
+// toggleIgnoreDiacritics toggles ignoring diacritics in search.
+func (root *Root) toggleIgnoreDiacritics(context.Context) {
+	root.Config.IgnoreDiacritics = !root.Config.IgnoreDiacritics
 	root.setPromptOpt()
 }
 
@@ -20,6 +20,8 @@ func converterCandidate() *candidate {
 			convEscaped,
 			convRaw,
 			convAlign,
+			convLogfmt,
+			convParser,
 		},
 	}
 }
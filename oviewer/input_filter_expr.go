@@ -0,0 +1,56 @@
+package oviewer
+
+import (
+	"context"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// inputFilterExpr sets the inputMode to FilterExpr.
+func (root *Root) inputFilterExpr(context.Context) {
+	input := root.input
+	input.reset()
+	input.Event = newFilterExprEvent(input.Candidate[FilterExpr])
+}
+
+// eventFilterExpr represents the filter expression input mode.
+type eventFilterExpr struct {
+	tcell.EventTime
+	clist *candidate
+	value string
+}
+
+// newFilterExprEvent returns eventFilterExpr.
+func newFilterExprEvent(clist *candidate) *eventFilterExpr {
+	return &eventFilterExpr{clist: clist}
+}
+
+// Mode returns InputMode.
+func (*eventFilterExpr) Mode() InputMode {
+	return FilterExpr
+}
+
+// Prompt returns the prompt string in the input field.
+func (*eventFilterExpr) Prompt() string {
+	return "Filter expr:"
+}
+
+// Confirm returns the event when the input is confirmed.
+func (e *eventFilterExpr) Confirm(str string) tcell.Event {
+	e.value = str
+	e.clist.toLast(str)
+	e.SetEventNow()
+	return e
+}
+
+// Up returns strings when the up key is pressed during input.
+func (e *eventFilterExpr) Up(str string) string {
+	e.clist.toAddLast(str)
+	return e.clist.up()
+}
+
+// Down returns strings when the down key is pressed during input.
+func (e *eventFilterExpr) Down(str string) string {
+	e.clist.toAddTop(str)
+	return e.clist.down()
+}
@@ -0,0 +1,56 @@
+package oviewer
+
+import (
+	"context"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// inputGoTime sets the inputMode to GotoTime.
+func (root *Root) inputGoTime(context.Context) {
+	input := root.input
+	input.reset()
+	input.Event = newGotoTimeEvent(input.Candidate[GotoTime])
+}
+
+// eventGotoTime represents the goto time input mode.
+type eventGotoTime struct {
+	tcell.EventTime
+	clist *candidate
+	value string
+}
+
+// newGotoTimeEvent returns eventGotoTime.
+func newGotoTimeEvent(clist *candidate) *eventGotoTime {
+	return &eventGotoTime{clist: clist}
+}
+
+// Mode returns InputMode.
+func (*eventGotoTime) Mode() InputMode {
+	return GotoTime
+}
+
+// Prompt returns the prompt string in the input field.
+func (*eventGotoTime) Prompt() string {
+	return "Goto time:"
+}
+
+// Confirm returns the event when the input is confirmed.
+func (e *eventGotoTime) Confirm(str string) tcell.Event {
+	e.value = str
+	e.clist.toLast(str)
+	e.SetEventNow()
+	return e
+}
+
+// Up returns strings when the up key is pressed during input.
+func (e *eventGotoTime) Up(str string) string {
+	e.clist.toAddLast(str)
+	return e.clist.up()
+}
+
+// Down returns strings when the down key is pressed during input.
+func (e *eventGotoTime) Down(str string) string {
+	e.clist.toAddTop(str)
+	return e.clist.down()
+}
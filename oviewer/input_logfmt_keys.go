@@ -0,0 +1,62 @@
+package oviewer
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// inputLogfmtKeys sets the inputMode to LogfmtKeys.
+func (root *Root) inputLogfmtKeys(context.Context) {
+	input := root.input
+	input.reset()
+
+	keys := root.Doc.logfmtConv.keys
+	input.Candidate[LogfmtKeys].toLast(strings.Join(keys, ","))
+	old := root.Doc.LogfmtHideKeys
+	input.Candidate[LogfmtKeys].toLast(strings.Join(old, ","))
+	input.Event = newLogfmtKeysEvent(input.Candidate[LogfmtKeys])
+}
+
+// eventLogfmtKeys represents the logfmt hide keys input mode.
+type eventLogfmtKeys struct {
+	tcell.EventTime
+	clist *candidate
+	value string
+}
+
+// newLogfmtKeysEvent returns eventLogfmtKeys.
+func newLogfmtKeysEvent(clist *candidate) *eventLogfmtKeys {
+	return &eventLogfmtKeys{clist: clist}
+}
+
+// Mode returns InputMode.
+func (*eventLogfmtKeys) Mode() InputMode {
+	return LogfmtKeys
+}
+
+// Prompt returns the prompt string in the input field.
+func (*eventLogfmtKeys) Prompt() string {
+	return "Hide keys:"
+}
+
+// Confirm returns the event when the input is confirmed.
+func (e *eventLogfmtKeys) Confirm(str string) tcell.Event {
+	e.value = str
+	e.clist.toLast(str)
+	e.SetEventNow()
+	return e
+}
+
+// Up returns strings when the up key is pressed during input.
+func (e *eventLogfmtKeys) Up(str string) string {
+	e.clist.toAddLast(str)
+	return e.clist.up()
+}
+
+// Down returns strings when the down key is pressed during input.
+func (e *eventLogfmtKeys) Down(str string) string {
+	e.clist.toAddTop(str)
+	return e.clist.down()
+}
@@ -63,9 +63,31 @@ func (root *Root) setPromptOpt() {
 	if root.Config.RegexpSearch {
 		opt.WriteString("(R)")
 	}
+	if root.Config.FuzzySearch {
+		opt.WriteString("(F)")
+	}
+	if root.Config.ExprSearch {
+		opt.WriteString("(E)")
+	}
+	if root.Config.RawSearch {
+		opt.WriteString("(Raw)")
+	}
+	if root.Config.IgnoreDiacritics {
+		opt.WriteString("(D)")
+	}
 	if mode != Filter && root.Config.Incsearch {
 		opt.WriteString("(I)")
 	}
+	if mode != Filter && root.Config.GlobalSearch {
+		opt.WriteString("(G)")
+	}
+	if mode != Filter && root.Config.WrapSearch {
+		opt.WriteString("(W)")
+	}
+	if root.Config.SectionSearch {
+		opt.WriteString("(Sec)")
+	}
+	opt.WriteString(root.Doc.searchColumnOpt())
 	if root.Config.SmartCaseSensitive {
 		opt.WriteString("(S)")
 	} else if root.Config.CaseSensitive {
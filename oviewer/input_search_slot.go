@@ -0,0 +1,56 @@
+package oviewer
+
+import (
+	"context"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// inputSearchSlot sets the inputMode to SearchSlot.
+func (root *Root) inputSearchSlot(context.Context) {
+	input := root.input
+	input.reset()
+	input.Event = newSearchSlotEvent(input.Candidate[SearchSlot])
+}
+
+// eventSearchSlot represents the search slot input mode.
+type eventSearchSlot struct {
+	tcell.EventTime
+	clist *candidate
+	value string
+}
+
+// newSearchSlotEvent returns eventSearchSlot.
+func newSearchSlotEvent(clist *candidate) *eventSearchSlot {
+	return &eventSearchSlot{clist: clist}
+}
+
+// Mode returns InputMode.
+func (*eventSearchSlot) Mode() InputMode {
+	return SearchSlot
+}
+
+// Prompt returns the prompt string in the input field.
+func (*eventSearchSlot) Prompt() string {
+	return "Search slot:"
+}
+
+// Confirm returns the event when the input is confirmed.
+func (e *eventSearchSlot) Confirm(str string) tcell.Event {
+	e.value = str
+	e.clist.toLast(str)
+	e.SetEventNow()
+	return e
+}
+
+// Up returns strings when the up key is pressed during input.
+func (e *eventSearchSlot) Up(str string) string {
+	e.clist.toAddLast(str)
+	return e.clist.up()
+}
+
+// Down returns strings when the down key is pressed during input.
+func (e *eventSearchSlot) Down(str string) string {
+	e.clist.toAddTop(str)
+	return e.clist.down()
+}
@@ -412,6 +412,15 @@ func TestRoot_inputState(t *testing.T) {
 	if root.searchOpt != "(R)(I)(S)" {
 		t.Errorf("Root.inputState() = %v, want %v", root.searchOpt, "(R)(I)(S)")
 	}
+	// Fuzzy search turns off regular expression search, and vice versa.
+	root.toggleFuzzySearch(ctx)
+	if root.searchOpt != "(F)(I)(S)" {
+		t.Errorf("Root.inputState() = %v, want %v", root.searchOpt, "(F)(I)(S)")
+	}
+	root.toggleRegexpSearch(ctx)
+	if root.searchOpt != "(R)(I)(S)" {
+		t.Errorf("Root.inputState() = %v, want %v", root.searchOpt, "(R)(I)(S)")
+	}
 	root.toggleNonMatch(ctx)
 	root.inputBackSearch(ctx)
 	if root.searchOpt != "(R)(I)(S)" {
@@ -36,14 +36,20 @@ const (
 	actionAlternate      = "alter_rows_mode"
 	actionLineNumMode    = "line_number_mode"
 	actionWrap           = "wrap_mode"
+	actionTableWrap      = "table_wrap"
 	actionColumnMode     = "column_mode"
 	actionColumnWidth    = "column_width"
 	actionNextSearch     = "next_search"
 	actionNextBackSearch = "next_backsearch"
+	actionNextSlot       = "next_slot"
+	actionPrevSlot       = "previous_slot"
 	actionNextDoc        = "next_doc"
 	actionPreviousDoc    = "previous_doc"
 	actionCloseDoc       = "close_doc"
 	actionCloseAllFilter = "close_all_filter"
+	actionOccur          = "occur"
+	actionOccurAll       = "occur_all"
+	actionOccurJump      = "occur_jump"
 	actionToggleMouse    = "toggle_mouse"
 	actionHideOther      = "hide_other"
 	actionStatusLine     = "status_line"
@@ -82,14 +88,18 @@ const (
 	actionConvertType    = "convert_type"
 	actionDelimiter      = "delimiter"
 	actionGoLine         = "goto"
+	actionGoTime         = "goto_time"
 	actionHeaderColumn   = "header_column"
 	actionHeader         = "header"
 	actionJumpTarget     = "jump_target"
+	actionLogfmtKeys     = "logfmt_hide_keys"
 	actionMultiColor     = "multi_color"
 	actionSaveBuffer     = "save_buffer"
 	actionSearch         = "search"
 	actionBackSearch     = "backsearch"
 	actionFilter         = "filter"
+	actionFilterExpr     = "filter_expr"
+	actionSearchSlot     = "search_slot"
 	actionSection        = "section_delimiter"
 	actionSectionNum     = "section_header_num"
 	actionSectionStart   = "section_start"
@@ -105,6 +115,14 @@ const (
 	inputSmartCaseSensitive = "input_smart_casesensitive"
 	inputIncSearch          = "input_incsearch"
 	inputRegexpSearch       = "input_regexp_search"
+	inputFuzzySearch        = "input_fuzzy_search"
+	inputIgnoreDiacritics   = "input_ignore_diacritics"
+	inputExprSearch         = "input_expr_search"
+	inputRawSearch          = "input_raw_search"
+	inputGlobalSearch       = "input_global_search"
+	inputWrapSearch         = "input_wrap_search"
+	inputSectionSearch      = "input_section_search"
+	inputColumnSearch       = "input_column_search"
 	inputNonMatch           = "input_non_match"
 	inputPrevious           = "input_previous"
 	inputNext               = "input_next"
@@ -114,7 +132,7 @@ const (
 
 // handlers returns a map of the action's handlers.
 func (root *Root) handlers() map[string]func(context.Context) {
-	return map[string]func(context.Context){
+	handlers := map[string]func(context.Context){
 		actionExit:           root.Quit,
 		actionCancel:         root.Cancel,
 		actionWriteExit:      root.WriteQuit,
@@ -137,14 +155,20 @@ func (root *Root) handlers() map[string]func(context.Context) {
 		actionAlternate:      root.toggleAlternateRows,
 		actionLineNumMode:    root.toggleLineNumMode,
 		actionWrap:           root.toggleWrapMode,
+		actionTableWrap:      root.toggleTableWrap,
 		actionColumnMode:     root.toggleColumnMode,
 		actionColumnWidth:    root.toggleColumnWidth,
 		actionNextSearch:     root.sendNextSearch,
 		actionNextBackSearch: root.sendNextBackSearch,
+		actionNextSlot:       root.nextSlotSearch,
+		actionPrevSlot:       root.prevSlotSearch,
 		actionNextDoc:        root.nextDoc,
 		actionPreviousDoc:    root.previousDoc,
 		actionCloseDoc:       root.closeDocument,
 		actionCloseAllFilter: root.closeAllFilter,
+		actionOccur:          root.occur,
+		actionOccurAll:       root.occurAll,
+		actionOccurJump:      root.occurJump,
 		actionToggleMouse:    root.toggleMouse,
 		actionHideOther:      root.toggleHideOtherSection,
 		actionStatusLine:     root.toggleStatusLine,
@@ -183,14 +207,18 @@ func (root *Root) handlers() map[string]func(context.Context) {
 		actionConvertType:    root.inputConvert,
 		actionDelimiter:      root.inputDelimiter,
 		actionGoLine:         root.inputGoLine,
+		actionGoTime:         root.inputGoTime,
 		actionHeaderColumn:   root.inputHeaderColumn,
 		actionHeader:         root.inputHeader,
 		actionJumpTarget:     root.inputJumpTarget,
+		actionLogfmtKeys:     root.inputLogfmtKeys,
 		actionMultiColor:     root.inputMultiColor,
 		actionSaveBuffer:     root.inputSaveBuffer,
 		actionSearch:         root.inputForwardSearch,
 		actionBackSearch:     root.inputBackSearch,
 		actionFilter:         root.inputSearchFilter,
+		actionFilterExpr:     root.inputFilterExpr,
+		actionSearchSlot:     root.inputSearchSlot,
 		actionSection:        root.inputSectionDelimiter,
 		actionSectionNum:     root.inputSectionNum,
 		actionSectionStart:   root.inputSectionStart,
@@ -206,12 +234,22 @@ func (root *Root) handlers() map[string]func(context.Context) {
 		inputSmartCaseSensitive: root.toggleSmartCaseSensitive,
 		inputIncSearch:          root.toggleIncSearch,
 		inputRegexpSearch:       root.toggleRegexpSearch,
+		inputFuzzySearch:        root.toggleFuzzySearch,
+		inputIgnoreDiacritics:   root.toggleIgnoreDiacritics,
+		inputExprSearch:         root.toggleExprSearch,
+		inputRawSearch:          root.toggleRawSearch,
+		inputGlobalSearch:       root.toggleGlobalSearch,
+		inputWrapSearch:         root.toggleWrapSearch,
+		inputSectionSearch:      root.toggleSectionSearch,
+		inputColumnSearch:       root.toggleColumnSearch,
 		inputNonMatch:           root.toggleNonMatch,
 		inputPrevious:           root.candidatePrevious,
 		inputNext:               root.candidateNext,
 		inputCopy:               root.CopySelect,
 		inputPaste:              root.Paste,
 	}
+	maps.Copy(handlers, root.slotHandlers())
+	return handlers
 }
 
 // KeyBind represents a mapping from action names to their associated key sequences.
@@ -241,14 +279,20 @@ func defaultKeyBinds() KeyBind {
 		// actionAlternate:      {"C"},
 		// actionLineNumMode:    {"G"},
 		// actionWrap:           {"w", "W"},
+		// actionTableWrap:      {"alt+w"},
 		// actionColumnMode:     {"c"},
 		// actionColumnWidth:    {"alt+o"},
 		// actionNextSearch:     {"n"},
 		// actionNextBackSearch: {"N"},
+		// actionNextSlot:       {"alt+."},
+		// actionPrevSlot:       {"alt+,"},
 		// actionNextDoc:        {"]"},
 		// actionPreviousDoc:    {"["},
 		// actionCloseDoc:       {"ctrl+k"},
 		// actionCloseAllFilter: {"K"},
+		// actionOccur:          {"O"},
+		// actionOccurAll:       {"alt+O"},
+		// actionOccurJump:      {"Enter"},
 		// actionToggleMouse:    {"ctrl+alt+r"},
 		// actionHideOther:      {"alt+-"},
 		// actionAlignFormat:    {"alt+F"},
@@ -286,14 +330,18 @@ func defaultKeyBinds() KeyBind {
 		// actionConvertType:    {"alt+t"},
 		// actionDelimiter:      {"d"},
 		// actionGoLine:         {"g"},
+		// actionGoTime:         {"@"},
 		// actionHeaderColumn:   {"Y"},
 		// actionHeader:         {"H"},
 		// actionJumpTarget:     {"j"},
+		// actionLogfmtKeys:     {"alt+K"},
 		// actionMultiColor:     {"."},
 		// actionSaveBuffer:     {"S"},
 		// actionSearch:         {"/"},
 		// actionBackSearch:     {"?"},
 		// actionFilter:         {"&"},
+		// actionFilterExpr:     {"alt+&"},
+		// actionSearchSlot:     {"alt+/"},
 		// actionSection:        {"alt+d"},
 		// actionSectionNum:     {"F7"},
 		// actionSectionStart:   {"ctrl+F3", "alt+s"},
@@ -309,6 +357,14 @@ func defaultKeyBinds() KeyBind {
 		// inputSmartCaseSensitive: {"alt+s"},
 		// inputIncSearch:          {"alt+i"},
 		// inputRegexpSearch:       {"alt+r"},
+		// inputFuzzySearch:        {"alt+z"},
+		// inputIgnoreDiacritics:   {"alt+e"},
+		// inputExprSearch:         {"alt+x"},
+		// inputRawSearch:          {"alt+b"},
+		// inputGlobalSearch:       {"alt+g"},
+		// inputWrapSearch:         {"alt+W"},
+		// inputSectionSearch:      {"alt+S"},
+		// inputColumnSearch:       {"alt+l"},
 		// inputNonMatch:           {"!"},
 		// inputPrevious:           {"Up"},
 		// inputNext:               {"Down"},
@@ -354,6 +410,7 @@ func (k KeyBind) String() string {
 	k.writeKeyBind(&b, actionMoveBeginLeft, "go to beginning of line")
 	k.writeKeyBind(&b, actionMoveEndRight, "go to end of line")
 	k.writeKeyBind(&b, actionGoLine, "go to line(input number or `.n` or `n%` allowed)")
+	k.writeKeyBind(&b, actionGoTime, "go to time(timestamp, `15:04` or `-15m` allowed)")
 
 	writeHeader(&b, "Move document")
 	k.writeKeyBind(&b, actionNextDoc, "next document")
@@ -374,6 +431,17 @@ func (k KeyBind) String() string {
 	k.writeKeyBind(&b, actionNextSearch, "repeat forward search")
 	k.writeKeyBind(&b, actionNextBackSearch, "repeat backward search")
 	k.writeKeyBind(&b, actionFilter, "filter search mode")
+	k.writeKeyBind(&b, actionFilterExpr, "filter by expression")
+	k.writeKeyBind(&b, actionOccur, "list lines matching the search")
+	k.writeKeyBind(&b, actionOccurAll, "list lines matching the search in all documents")
+	k.writeKeyBind(&b, actionOccurJump, "jump to the line of the occur list")
+	k.writeKeyBind(&b, actionSearchSlot, "set search slot(`n:word`)")
+	k.writeKeyBind(&b, actionNextSlot, "next match of any search slot")
+	k.writeKeyBind(&b, actionPrevSlot, "previous match of any search slot")
+	for num := 1; num <= searchSlotNum; num++ {
+		k.writeKeyBind(&b, slotAction(actionNextSlot, num), fmt.Sprintf("next match of search slot %d", num))
+		k.writeKeyBind(&b, slotAction(actionPrevSlot, num), fmt.Sprintf("previous match of search slot %d", num))
+	}
 
 	writeHeader(&b, "Change display")
 	k.writeKeyBind(&b, actionWrap, "wrap/nowrap toggle")
@@ -404,6 +472,8 @@ func (k KeyBind) String() string {
 	k.writeKeyBind(&b, actionFixedColumn, "header column fixed toggle")
 	k.writeKeyBind(&b, actionShrinkColumn, "shrink column toggle(align mode only)")
 	k.writeKeyBind(&b, actionRightAlign, "right align column toggle(align mode only)")
+	k.writeKeyBind(&b, actionTableWrap, "wrap cells within columns toggle(align mode only)")
+	k.writeKeyBind(&b, actionLogfmtKeys, "keys to hide(logfmt converter only)")
 
 	writeHeader(&b, "Section operation")
 	k.writeKeyBind(&b, actionSection, "section delimiter regular expression")
@@ -425,7 +495,15 @@ func (k KeyBind) String() string {
 	k.writeKeyBind(&b, inputCaseSensitive, "case-sensitive toggle")
 	k.writeKeyBind(&b, inputSmartCaseSensitive, "smart case-sensitive toggle")
 	k.writeKeyBind(&b, inputRegexpSearch, "regular expression search toggle")
+	k.writeKeyBind(&b, inputFuzzySearch, "fuzzy search toggle")
+	k.writeKeyBind(&b, inputIgnoreDiacritics, "ignore diacritics toggle")
+	k.writeKeyBind(&b, inputExprSearch, "search expression toggle")
+	k.writeKeyBind(&b, inputRawSearch, "raw search toggle")
 	k.writeKeyBind(&b, inputIncSearch, "incremental search toggle")
+	k.writeKeyBind(&b, inputGlobalSearch, "global search toggle")
+	k.writeKeyBind(&b, inputWrapSearch, "wrap-around search toggle")
+	k.writeKeyBind(&b, inputSectionSearch, "search in the current section toggle")
+	k.writeKeyBind(&b, inputColumnSearch, "search in the cursor column toggle")
 	k.writeKeyBind(&b, inputNonMatch, "non-match toggle")
 	k.writeKeyBind(&b, inputPrevious, "previous candidate")
 	k.writeKeyBind(&b, inputNext, "next candidate")
@@ -473,6 +551,7 @@ func GetKeyBinds(config Config) KeyBind {
 func (root *Root) setHandlers(ctx context.Context, keyBind KeyBind) error {
 	c := root.keyConfig
 	in := root.inputKeyConfig
+	occur := root.occurKeyConfig
 
 	actionHandlers := root.handlers()
 
@@ -488,6 +567,12 @@ func (root *Root) setHandlers(ctx context.Context, keyBind KeyBind) error {
 			}
 			continue
 		}
+		if name == actionOccurJump {
+			if err := setHandler(ctx, occur, name, keys, handler); err != nil {
+				return err
+			}
+			continue
+		}
 		if err := setHandler(ctx, c, name, keys, handler); err != nil {
 			return err
 		}
@@ -574,7 +659,8 @@ func normalizeKey(key string) (string, error) {
 	return encoded, nil
 }
 
-// normalizeKeyWithPrefix normalizes a key and adds input_ prefix if the action is an input action.
+// normalizeKeyWithPrefix normalizes a key and adds input_ prefix if the action is an input action,
+// or occur_ prefix if the action is an action of the occur document.
 func normalizeKeyWithPrefix(key, action string) (string, error) {
 	normalizedKey, err := normalizeKey(key)
 	if err != nil {
@@ -584,6 +670,9 @@ func normalizeKeyWithPrefix(key, action string) (string, error) {
 	if strings.HasPrefix(action, "input_") {
 		return "input_" + normalizedKey, nil
 	}
+	if action == actionOccurJump {
+		return "occur_" + normalizedKey, nil
+	}
 	return normalizedKey, nil
 }
 
@@ -0,0 +1,326 @@
+package oviewer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// matchCount is the number of matches of the search.
+// It is counted in the background chunk by chunk.
+type matchCount struct {
+	mu sync.Mutex
+	// cancel cancels the counting.
+	cancel context.CancelFunc
+	// key identifies the searcher being counted.
+	key string
+	// chunks is the number of matches in each chunk.
+	// It is uncountedChunk if the lines of the chunk cannot be read.
+	chunks []int
+	// endNum is the number of lines that have been counted.
+	endNum int
+	// counting is true while counting.
+	counting bool
+
+	// indexLN is the line number of the cached index.
+	indexLN int
+	// index is the cached position of the first match of indexLN among the matches in its chunk (0 if not a match).
+	index int
+	// indexValid is true if the index is cached.
+	indexValid bool
+}
+
+// uncountedChunk is the count of a chunk whose lines cannot be read,
+// such as the lines already freed from a non-seekable document.
+const uncountedChunk = -1
+
+// searcherKey returns the key that identifies the searcher.
+// A new Searcher is created for each search, so the type, the word
+// and the options that change the matches (such as case sensitivity) are compared.
+// The regular expression engine is distinguished by the type.
+func searcherKey(searcher Searcher) string {
+	var b strings.Builder
+	writeSearcherKey(&b, searcher)
+	return b.String()
+}
+
+// writeSearcherKey writes the key of the searcher to b.
+func writeSearcherKey(b *strings.Builder, searcher Searcher) {
+	fmt.Fprintf(b, "%T:%s", searcher, searcher.String())
+	switch s := searcher.(type) {
+	case regexpWord:
+		// The compiled expression has the case-insensitive flag.
+		fmt.Fprintf(b, ":%s", s.regexp)
+	case backtrackWord:
+		fmt.Fprintf(b, ":%v", s.caseSensitive)
+	case fuzzyWord:
+		fmt.Fprintf(b, ":%v", s.caseSensitive)
+	case keyPredicate:
+		fmt.Fprintf(b, ":%v", s.caseSensitive)
+	case rawWord:
+		fmt.Fprintf(b, ":%v:%v", s.caseSensitive, s.regexp)
+	case normalizedWord:
+		fmt.Fprintf(b, ":%v:%v(", s.normalizer.form, s.normalizer.foldDiacritics)
+		writeSearcherKey(b, s.searcher)
+		b.WriteString(")")
+	case columnSearcher:
+		fmt.Fprintf(b, ":%d:%s(", s.column, s.key)
+		writeSearcherKey(b, s.Searcher)
+		b.WriteString(")")
+	case slotSearcher:
+		for _, slot := range s.searchers {
+			b.WriteString("(")
+			writeSearcherKey(b, slot)
+			b.WriteString(")")
+		}
+	case searchExpr:
+		writeExprKey(b, s.root)
+	}
+}
+
+// writeExprKey writes the key of the terms of the expression node to b.
+func writeExprKey(b *strings.Builder, n *exprNode) {
+	if n == nil {
+		return
+	}
+	fmt.Fprintf(b, "(%d", n.op)
+	if n.searcher != nil {
+		writeSearcherKey(b, n.searcher)
+	}
+	for _, c := range n.children {
+		writeExprKey(b, c)
+	}
+	b.WriteString(")")
+}
+
+// startMatchCount starts counting the matches of the searcher in the background.
+// If the same searcher has already been counted, only the added lines are counted.
+// lN is the current matching line, whose position is calculated before counting
+// because its chunk is in memory now.
+// notify is called each time the count is updated.
+func (m *Document) startMatchCount(ctx context.Context, searcher Searcher, lN int, notify func()) {
+	if searcher == nil {
+		return
+	}
+	key := searcherKey(searcher)
+	mc := &m.matchCount
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	if mc.key != key {
+		if mc.cancel != nil {
+			mc.cancel()
+		}
+		mc.key = key
+		mc.chunks = nil
+		mc.endNum = 0
+		mc.counting = false
+		mc.indexValid = false
+	}
+	m.cacheMatchIndex(searcher, lN)
+	if mc.counting || (len(mc.chunks) > 0 && mc.endNum >= m.BufEndNum()) {
+		return
+	}
+
+	// The last chunk may have been counted halfway, so count it again.
+	startChunk, _ := chunkLineNum(mc.endNum)
+	mc.chunks = mc.chunks[:min(startChunk, len(mc.chunks))]
+	startChunk = len(mc.chunks)
+	ctx, cancel := context.WithCancel(ctx)
+	mc.cancel = cancel
+	mc.counting = true
+	go m.countMatches(ctx, searcher, startChunk, notify)
+}
+
+// cancelMatchCount cancels the counting and clears the count.
+func (m *Document) cancelMatchCount() {
+	mc := &m.matchCount
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	if mc.cancel != nil {
+		mc.cancel()
+	}
+	mc.key = ""
+	mc.chunks = nil
+	mc.endNum = 0
+	mc.counting = false
+	mc.indexValid = false
+}
+
+// countMatches counts the matches from startChunk to the end of the document.
+func (m *Document) countMatches(ctx context.Context, searcher Searcher, startChunk int, notify func()) {
+	mc := &m.matchCount
+	endNum := m.BufEndNum()
+	f := m.chunkFile()
+	if f != nil {
+		defer f.Close()
+	}
+	for chunkNum := startChunk; chunkNum*ChunkSize < endNum; chunkNum++ {
+		count, err := m.countChunk(ctx, f, searcher, chunkNum)
+		if errors.Is(err, ErrCancel) {
+			return
+		}
+		if err != nil {
+			count = uncountedChunk
+		}
+		mc.mu.Lock()
+		if ctx.Err() != nil {
+			mc.mu.Unlock()
+			return
+		}
+		mc.chunks = append(mc.chunks, count)
+		mc.mu.Unlock()
+		notify()
+	}
+
+	mc.mu.Lock()
+	if ctx.Err() == nil {
+		mc.endNum = endNum
+		mc.counting = false
+	}
+	mc.mu.Unlock()
+	notify()
+}
+
+// countChunk returns the number of matches in the chunk.
+// Chunks that are not in memory are read from the file without being loaded.
+// It returns an error if the lines cannot be read,
+// such as the lines that have already been freed (non-seekable).
+func (m *Document) countChunk(ctx context.Context, f *os.File, searcher Searcher, chunkNum int) (int, error) {
+	if m.skipChunk(searcher, chunkNum) {
+		return 0, nil
+	}
+	count := 0
+	canceled := false
+	if err := m.chunkLines(f, chunkNum, func(_ int, line []byte) bool {
+		if ctx.Err() != nil {
+			canceled = true
+			return false
+		}
+		count += lineMatches(searcher, line)
+		return true
+	}); err != nil {
+		return 0, err
+	}
+	if canceled {
+		return 0, ErrCancel
+	}
+	return count, nil
+}
+
+// lineMatches returns the number of matches in the line.
+func lineMatches(searcher Searcher, line []byte) int {
+	if !searcher.Match(line) {
+		return 0
+	}
+	return max(len(searcher.FindAll(string(line))), 1)
+}
+
+// matchTotal returns the number of matches of the searcher and whether it is still counting.
+// The number is uncountedChunk if some chunks cannot be counted.
+// The last value is false if the searcher is not counted.
+func (m *Document) matchTotal(searcher Searcher) (int, bool, bool) {
+	mc := &m.matchCount
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	if searcher == nil || mc.key != searcherKey(searcher) {
+		return 0, false, false
+	}
+	return sumChunks(mc.chunks), mc.counting, true
+}
+
+// sumChunks returns the sum of the counts of the chunks.
+// It returns uncountedChunk if any of the chunks cannot be counted.
+func sumChunks(chunks []int) int {
+	total := 0
+	for _, c := range chunks {
+		if c == uncountedChunk {
+			return uncountedChunk
+		}
+		total += c
+	}
+	return total
+}
+
+// cacheMatchIndex caches the position of the first match of lN among the matches in its chunk.
+// It must be called with mc.mu locked.
+func (m *Document) cacheMatchIndex(searcher Searcher, lN int) {
+	mc := &m.matchCount
+	if lN < 0 || (mc.indexValid && mc.indexLN == lN) {
+		return
+	}
+	chunkNum, cn := chunkLineNum(lN)
+	if !m.store.isLoadedChunk(chunkNum, m.seekable) {
+		return
+	}
+	index := 0
+	for n := 0; n <= cn; n++ {
+		buf, err := m.store.GetChunkLine(chunkNum, n)
+		if err != nil {
+			return
+		}
+		if n < cn {
+			index += lineMatches(searcher, buf)
+		} else if searcher.Match(buf) {
+			index++
+		} else {
+			index = 0
+		}
+	}
+	mc.indexLN, mc.index, mc.indexValid = lN, index, true
+}
+
+// matchIndex returns the position (1-based) of the first match of the line among the matches.
+// Returns false if the line does not match or the position is not yet known.
+func (m *Document) matchIndex(searcher Searcher, lN int) (int, bool) {
+	if searcher == nil || lN < 0 {
+		return 0, false
+	}
+	chunkNum, _ := chunkLineNum(lN)
+	mc := &m.matchCount
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	if mc.key != searcherKey(searcher) {
+		return 0, false
+	}
+	m.cacheMatchIndex(searcher, lN)
+	if !mc.indexValid || mc.indexLN != lN || mc.index == 0 || len(mc.chunks) <= chunkNum {
+		return 0, false
+	}
+	before := sumChunks(mc.chunks[:chunkNum])
+	if before == uncountedChunk {
+		return 0, false
+	}
+	return before + mc.index, true
+}
+
+// matchStatus returns the match count of the current search as "match 37/1203".
+// Returns an empty string if there is no count.
+func (root *Root) matchStatus() string {
+	m := root.Doc
+	total, counting, ok := m.matchTotal(root.searcher)
+	if !ok {
+		return ""
+	}
+	current := "-"
+	if index, ok := m.matchIndex(root.searcher, m.matchLN); ok {
+		current = strconv.Itoa(index)
+	}
+	if counting {
+		return fmt.Sprintf("match %s/counting... ", current)
+	}
+	if total == uncountedChunk {
+		return fmt.Sprintf("match %s/? ", current)
+	}
+	return fmt.Sprintf("match %s/%d ", current, total)
+}
+
+// sendMatchCount fires the eventUpdateEndNum event to redraw the status line.
+func (root *Root) sendMatchCount() {
+	ev := &eventUpdateEndNum{}
+	ev.SetEventNow()
+	root.postEvent(ev)
+}
@@ -0,0 +1,210 @@
+package oviewer
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func matchCountHelper(t *testing.T, m *Document, searcher Searcher, lN int) {
+	t.Helper()
+	m.startMatchCount(context.Background(), searcher, lN, func() {})
+	for range 500 {
+		if _, counting, ok := m.matchTotal(searcher); ok && !counting {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("timeout counting matches")
+}
+
+func TestDocument_matchCount(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		searcher Searcher
+		lN       int
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantTotal int
+		wantIndex int
+		wantOK    bool
+	}{
+		{
+			name: "firstChunk",
+			args: args{
+				searcher: NewSearcher("123", nil, false, false),
+				lN:       122,
+			},
+			wantTotal: 79,
+			wantIndex: 1,
+			wantOK:    true,
+		},
+		{
+			name: "secondChunk",
+			args: args{
+				searcher: NewSearcher("123", nil, false, false),
+				lN:       10122,
+			},
+			wantTotal: 79,
+			wantIndex: 21,
+			wantOK:    true,
+		},
+		{
+			name: "notMatch",
+			args: args{
+				searcher: NewSearcher("99", nil, false, false),
+				lN:       0,
+			},
+			// "9999" has two matches.
+			wantTotal: 322,
+			wantIndex: 0,
+			wantOK:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := docFileReadHelper(t, filepath.Join(testdata, "test3.txt"))
+			// The current line has been loaded by the search.
+			chunkNum, _ := chunkLineNum(tt.args.lN)
+			m.requestLoadSync(chunkNum)
+			matchCountHelper(t, m, tt.args.searcher, tt.args.lN)
+			total, _, _ := m.matchTotal(tt.args.searcher)
+			if total != tt.wantTotal {
+				t.Errorf("matchTotal() = %v, want %v", total, tt.wantTotal)
+			}
+			index, ok := m.matchIndex(tt.args.searcher, tt.args.lN)
+			if index != tt.wantIndex || ok != tt.wantOK {
+				t.Errorf("matchIndex() = %v, %v, want %v, %v", index, ok, tt.wantIndex, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestDocument_matchCountOtherSearcher(t *testing.T) {
+	t.Parallel()
+	m := docHelper(t, "a\nb\na\n")
+	matchCountHelper(t, m, NewSearcher("a", nil, false, false), 0)
+	if _, _, ok := m.matchTotal(NewSearcher("b", nil, false, false)); ok {
+		t.Errorf("matchTotal() should not return the count of the other searcher")
+	}
+	if _, _, ok := m.matchTotal(NewSearcher("A", nil, true, false)); ok {
+		t.Errorf("matchTotal() should not return the count of the case-sensitive searcher")
+	}
+	matchCountHelper(t, m, NewSearcher("b", nil, false, false), 1)
+	if total, _, ok := m.matchTotal(NewSearcher("b", nil, false, false)); !ok || total != 1 {
+		t.Errorf("matchTotal() = %v, %v, want 1, true", total, ok)
+	}
+	m.cancelMatchCount()
+	if _, _, ok := m.matchTotal(NewSearcher("a", nil, false, false)); ok {
+		t.Errorf("matchTotal() should return false after cancel")
+	}
+}
+
+func TestDocument_matchCountMatches(t *testing.T) {
+	t.Parallel()
+	m := docHelper(t, "a a\nb\na b a\n")
+	searcher := NewSearcher("a", nil, false, false)
+	matchCountHelper(t, m, searcher, 2)
+	// Each match is counted, not the matching lines.
+	if total, _, ok := m.matchTotal(searcher); !ok || total != 4 {
+		t.Errorf("matchTotal() = %v, %v, want 4, true", total, ok)
+	}
+	if index, ok := m.matchIndex(searcher, 2); !ok || index != 3 {
+		t.Errorf("matchIndex() = %v, %v, want 3, true", index, ok)
+	}
+}
+
+func TestDocument_matchCountUnloaded(t *testing.T) {
+	t.Parallel()
+	m := docFileReadHelper(t, filepath.Join(testdata, "test3.txt"))
+	m.store.unloadChunk(1)
+	searcher := NewSearcher("123", nil, false, false)
+	matchCountHelper(t, m, searcher, 122)
+	if total, _, _ := m.matchTotal(searcher); total != 79 {
+		t.Errorf("matchTotal() = %v, want 79", total)
+	}
+	// The chunk is read from the file without being loaded.
+	if m.store.isLoadedChunk(1, m.seekable) {
+		t.Errorf("chunk 1 is loaded by counting")
+	}
+}
+
+func TestDocument_matchCountUncounted(t *testing.T) {
+	t.Parallel()
+	m := docHelper(t, strings.Repeat("a\n", ChunkSize*2))
+	// The lines of the non-seekable document have been freed.
+	m.store.unloadChunk(1)
+	searcher := NewSearcher("a", nil, false, false)
+	matchCountHelper(t, m, searcher, 0)
+	if total, _, ok := m.matchTotal(searcher); !ok || total != uncountedChunk {
+		t.Errorf("matchTotal() = %v, %v, want %v, true", total, ok, uncountedChunk)
+	}
+	if index, ok := m.matchIndex(searcher, 0); !ok || index != 1 {
+		t.Errorf("matchIndex() = %v, %v, want 1, true", index, ok)
+	}
+}
+
+func Test_searcherKey(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		a    Searcher
+		b    Searcher
+		same bool
+	}{
+		{
+			name: "sameWord",
+			a:    NewSearcher("a.c", regexpCompile("a.c", false), false, true),
+			b:    NewSearcher("a.c", regexpCompile("a.c", false), false, true),
+			same: true,
+		},
+		{
+			name: "regexpCase",
+			a:    NewSearcher("a.c", regexpCompile("a.c", false), false, true),
+			b:    NewSearcher("a.c", regexpCompile("a.c", true), true, true),
+			same: false,
+		},
+		{
+			name: "fuzzyCase",
+			a:    newFuzzyWord("abc", false),
+			b:    newFuzzyWord("abc", true),
+			same: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := searcherKey(tt.a) == searcherKey(tt.b); got != tt.same {
+				t.Errorf("searcherKey() same = %v, want %v (%q, %q)", got, tt.same, searcherKey(tt.a), searcherKey(tt.b))
+			}
+		})
+	}
+}
+
+func TestRoot_matchStatus(t *testing.T) {
+	tcellNewScreen = fakeScreen
+	defer func() {
+		tcellNewScreen = tcell.NewScreen
+	}()
+	root := rootFileReadHelper(t, filepath.Join(testdata, "test3.txt"))
+	if got := root.matchStatus(); got != "" {
+		t.Errorf("matchStatus() = %q, want empty", got)
+	}
+	searcher := root.setSearcher("123", false)
+	root.Doc.matchLN = 1122
+	matchCountHelper(t, root.Doc, searcher, root.Doc.matchLN)
+	if got, want := root.matchStatus(), "match 2/79 "; got != want {
+		t.Errorf("matchStatus() = %q, want %q", got, want)
+	}
+	root.Doc.matchLN = 0
+	if got, want := root.matchStatus(), "match -/79 "; got != want {
+		t.Errorf("matchStatus() = %q, want %q", got, want)
+	}
+}
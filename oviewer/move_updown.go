@@ -257,16 +257,22 @@ func leftX(width int, lc contents) []int {
 	return listX
 }
 
+// sectionDelimiterSearcher returns the searcher of the section delimiter.
+func (m *Document) sectionDelimiterSearcher() Searcher {
+	if m.sectionSearcher != nil {
+		return m.sectionSearcher
+	}
+	return NewSearcher(m.SectionDelimiter, m.SectionDelimiterReg, true, true)
+}
+
 // nextSection returns the line number of the next section.
 func (m *Document) nextSection(ctx context.Context, lN int) (int, error) {
-	searcher := NewSearcher(m.SectionDelimiter, m.SectionDelimiterReg, true, true)
-	return m.SearchLine(ctx, searcher, lN+1)
+	return m.SearchLine(ctx, m.sectionDelimiterSearcher(), lN+1)
 }
 
 // prevSection returns the line number of the previous section.
 func (m *Document) prevSection(ctx context.Context, lN int) (int, error) {
-	searcher := NewSearcher(m.SectionDelimiter, m.SectionDelimiterReg, true, true)
-	return m.BackSearchLine(ctx, searcher, lN-1)
+	return m.BackSearchLine(ctx, m.sectionDelimiterSearcher(), lN-1)
 }
 
 // moveNextSection moves to the next section.
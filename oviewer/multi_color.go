@@ -0,0 +1,167 @@
+package oviewer
+
+import (
+	"regexp"
+)
+
+// ahoCorasick is an Aho-Corasick automaton that finds many literal words in a single pass.
+type ahoCorasick struct {
+	nodes []acNode
+	// lengths is the length of each word.
+	lengths map[int]int
+}
+
+// acNode is a node of the ahoCorasick automaton.
+type acNode struct {
+	next map[byte]int
+	// fail is the node of the longest proper suffix that is also a prefix of a word.
+	fail int
+	// out is the words that end at this node, including those of the fail nodes.
+	out []int
+}
+
+// acMatch is a match of the ahoCorasick automaton.
+type acMatch struct {
+	word  int
+	start int
+	end   int
+}
+
+// newAhoCorasick returns an ahoCorasick automaton that finds words.
+// words is a map of the word number to the word.
+func newAhoCorasick(words map[int]string) *ahoCorasick {
+	ac := &ahoCorasick{
+		nodes:   []acNode{{next: make(map[byte]int)}},
+		lengths: make(map[int]int, len(words)),
+	}
+	for num, word := range words {
+		n := 0
+		for i := range len(word) {
+			c := word[i]
+			next, ok := ac.nodes[n].next[c]
+			if !ok {
+				next = len(ac.nodes)
+				ac.nodes = append(ac.nodes, acNode{next: make(map[byte]int)})
+				ac.nodes[n].next[c] = next
+			}
+			n = next
+		}
+		ac.nodes[n].out = append(ac.nodes[n].out, num)
+		ac.lengths[num] = len(word)
+	}
+
+	// Set the fail links in breadth-first order.
+	queue := make([]int, 0, len(ac.nodes))
+	for _, next := range ac.nodes[0].next {
+		queue = append(queue, next)
+	}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		for c, next := range ac.nodes[n].next {
+			f := ac.nodes[n].fail
+			for f > 0 && !ac.hasNext(f, c) {
+				f = ac.nodes[f].fail
+			}
+			if fn, ok := ac.nodes[f].next[c]; ok && fn != next {
+				f = fn
+			} else {
+				f = 0
+			}
+			ac.nodes[next].fail = f
+			ac.nodes[next].out = append(ac.nodes[next].out, ac.nodes[f].out...)
+			queue = append(queue, next)
+		}
+	}
+	return ac
+}
+
+// hasNext returns true if the node has a transition by c.
+func (ac *ahoCorasick) hasNext(n int, c byte) bool {
+	_, ok := ac.nodes[n].next[c]
+	return ok
+}
+
+// findAll returns all matches of the words in s, in order of the end position.
+func (ac *ahoCorasick) findAll(s string) []acMatch {
+	var matches []acMatch
+	n := 0
+	for i := range len(s) {
+		c := s[i]
+		for n > 0 && !ac.hasNext(n, c) {
+			n = ac.nodes[n].fail
+		}
+		n = ac.nodes[n].next[c]
+		for _, num := range ac.nodes[n].out {
+			matches = append(matches, acMatch{word: num, start: i + 1 - ac.lengths[num], end: i + 1})
+		}
+	}
+	return matches
+}
+
+// multiColorMatcher finds the positions of multiple words.
+// Plain words are found together by the Aho-Corasick automaton,
+// and only the words that are real regular expressions are matched by each regular expression.
+type multiColorMatcher struct {
+	// literal is nil if there are no plain words.
+	literal *ahoCorasick
+	// regexps is the regular expression of each word, which is nil for plain words.
+	regexps []*regexp.Regexp
+	// backtracks is the searcher of the words that use the backtracking engine.
+	backtracks map[int]Searcher
+}
+
+// newMultiColorMatcher returns a multiColorMatcher for the words.
+// engine is the regular expression engine.
+func newMultiColorMatcher(words []string, engine string) *multiColorMatcher {
+	regexps := multiRegexpCompile(words)
+	literals := make(map[int]string)
+	backtracks := make(map[int]Searcher)
+	for n, re := range regexps {
+		if searcher := newRegexpSearcher(unquoteWord(words[n]), engine, true); searcher != nil {
+			backtracks[n] = searcher
+			regexps[n] = nil
+			continue
+		}
+		if re == nil {
+			continue
+		}
+		if prefix, complete := re.LiteralPrefix(); complete && prefix != "" {
+			literals[n] = prefix
+			regexps[n] = nil
+		}
+	}
+	matcher := &multiColorMatcher{regexps: regexps, backtracks: backtracks}
+	if len(literals) > 0 {
+		matcher.literal = newAhoCorasick(literals)
+	}
+	return matcher
+}
+
+// findAll returns the positions of each word in s.
+// As with regular expressions, the matches of a word do not overlap each other.
+func (m *multiColorMatcher) findAll(s string) [][][]int {
+	if m == nil {
+		return nil
+	}
+	indexes := make([][][]int, len(m.regexps))
+	if m.literal != nil {
+		for _, match := range m.literal.findAll(s) {
+			idx := indexes[match.word]
+			if len(idx) > 0 && idx[len(idx)-1][1] > match.start {
+				continue
+			}
+			indexes[match.word] = append(idx, []int{match.start, match.end})
+		}
+	}
+	for n, re := range m.regexps {
+		if re == nil {
+			continue
+		}
+		indexes[n] = searchPositionReg(s, re)
+	}
+	for n, searcher := range m.backtracks {
+		indexes[n] = searcher.FindAll(s)
+	}
+	return indexes
+}
@@ -0,0 +1,108 @@
+package oviewer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_ahoCorasick_findAll(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		words map[int]string
+		s     string
+		want  []acMatch
+	}{
+		{
+			name:  "classic",
+			words: map[int]string{0: "he", 1: "she", 2: "his", 3: "hers"},
+			s:     "ushers",
+			want: []acMatch{
+				{word: 1, start: 1, end: 4},
+				{word: 0, start: 2, end: 4},
+				{word: 3, start: 2, end: 6},
+			},
+		},
+		{
+			name:  "failLink",
+			words: map[int]string{0: "abcd", 1: "bc"},
+			s:     "abcbc",
+			want: []acMatch{
+				{word: 1, start: 1, end: 3},
+				{word: 1, start: 3, end: 5},
+			},
+		},
+		{
+			name:  "noMatch",
+			words: map[int]string{0: "error"},
+			s:     "warning",
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := newAhoCorasick(tt.words).findAll(tt.s); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("findAll() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_multiColorMatcher_findAll(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		words       []string
+		s           string
+		want        [][][]int
+		wantRegexps int
+	}{
+		{
+			name:        "literal",
+			words:       []string{"ERROR", "WARN"},
+			s:           "ERROR WARN ERROR",
+			want:        [][][]int{{{0, 5}, {11, 16}}, {{6, 10}}},
+			wantRegexps: 0,
+		},
+		{
+			name:        "overlap",
+			words:       []string{"aa"},
+			s:           "aaaaa",
+			want:        [][][]int{{{0, 2}, {2, 4}}},
+			wantRegexps: 0,
+		},
+		{
+			name:        "mixed",
+			words:       []string{"ERROR", "^.{3}", "["},
+			s:           "[a]ERROR",
+			want:        [][][]int{{{3, 8}}, {{0, 3}}, {{0, 1}}},
+			wantRegexps: 1,
+		},
+		{
+			name:        "quoted",
+			words:       []string{`"a b"`},
+			s:           "a b a b",
+			want:        [][][]int{{{0, 3}, {4, 7}}},
+			wantRegexps: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := newMultiColorMatcher(tt.words, "")
+			regexps := 0
+			for _, re := range m.regexps {
+				if re != nil {
+					regexps++
+				}
+			}
+			if regexps != tt.wantRegexps {
+				t.Errorf("newMultiColorMatcher() regexps = %d, want %d", regexps, tt.wantRegexps)
+			}
+			if got := m.findAll(tt.s); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("findAll() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package oviewer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"slices"
+	"time"
+	"unicode/utf8"
+)
+
+// occurRefreshInterval is the interval to check whether the parent document has grown.
+var occurRefreshInterval = 500 * time.Millisecond
+
+// occurDocument is a document that lists the lines matching the search.
+type occurDocument struct {
+	*Document
+	w io.WriteCloser
+	// global is true if the lines of all documents are listed.
+	global bool
+}
+
+// occur lists the lines that match the current search in a new document.
+func (root *Root) occur(ctx context.Context) {
+	if root.searcher == nil {
+		root.setMessage("no search word")
+		return
+	}
+	root.occurDocument(ctx, root.searcher)
+}
+
+// occurDocument creates a new document that lists the lines matching the searcher.
+// Each line is prefixed with the line number and the number of matches.
+func (root *Root) occurDocument(ctx context.Context, searcher Searcher) {
+	m := root.Doc
+	r, w := io.Pipe()
+	render, err := renderDoc(m, r)
+	if err != nil {
+		log.Printf("failed to occur document: %v\n", err)
+		return
+	}
+	render.documentType = DocOccur
+	render.Caption = "occur:" + searcher.String()
+	root.insertDocument(ctx, root.CurrentDoc, render)
+	render.RunTimeSettings = occurSettings(m.RunTimeSettings)
+	render.regexpCompile()
+	render.conv = render.converterType(render.Converter)
+	occurDoc := &occurDocument{
+		Document: render,
+		w:        w,
+	}
+	if s, ok := searcher.(scorer); ok {
+		go root.occurRankedWriter(ctx, m, s, occurDoc)
+	} else {
+		go root.occurWriter(ctx, m, searcher, occurDoc)
+	}
+	root.setMessagef("occur:%s", searcher.String())
+}
+
+// occurSettings returns the settings of the occur document.
+// The lines are prefixed, so the column and section settings of the parent are not applied.
+func occurSettings(settings RunTimeSettings) RunTimeSettings {
+	settings.Converter = convEscaped
+	settings.Header = 0
+	settings.SkipLines = 0
+	settings.VerticalHeader = 0
+	settings.HeaderColumn = 0
+	settings.ColumnMode = false
+	settings.ColumnWidth = false
+	settings.SectionDelimiter = ""
+	settings.SectionHeader = false
+	settings.SectionHeaderNum = 0
+	settings.HideOtherSection = false
+	settings.FollowMode = false
+	settings.FollowSection = false
+	return settings
+}
+
+// occurWriter writes the matching lines to occurDoc.
+// After reaching the end, it continues to write the lines added to the parent document
+// until the occur document is closed.
+func (root *Root) occurWriter(ctx context.Context, m *Document, searcher Searcher, occurDoc *occurDocument) {
+	defer occurDoc.w.Close()
+	ticker := time.NewTicker(occurRefreshInterval)
+	defer ticker.Stop()
+
+	msg := "occur:" + searcher.String()
+	progress := &searchProgress{}
+	ctx = withProgress(ctx, progress)
+	originLN, renderLN := m.firstLine(), 0
+	for {
+		endNum := m.BufEndNum()
+		if endNum > originLN {
+			stop := root.progressTicker(progress, msg)
+			originLN, renderLN = m.occurWrite(ctx, searcher, originLN, renderLN, occurDoc)
+			stop()
+			originLN = max(originLN, endNum)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if root.documentIndex(occurDoc.Document) < 0 {
+			return
+		}
+	}
+}
+
+// occurWrite searches from originLN and writes the matching lines to occurDoc.
+// It returns the next line number to search and the next line number of occurDoc.
+func (m *Document) occurWrite(ctx context.Context, searcher Searcher, originLN int, renderLN int, occurDoc *occurDocument) (int, int) {
+	startChunk, _ := chunkLineNum(originLN)
+	defer m.startProgress(ctx, startChunk, 0, true)()
+	for {
+		select {
+		case <-ctx.Done():
+			return originLN, renderLN
+		default:
+		}
+		lineNum, err := m.searchLine(ctx, searcher, true, originLN)
+		if err != nil {
+			// Not found
+			return originLN, renderLN
+		}
+		line, err := m.Line(lineNum)
+		if err != nil {
+			// deleted?
+			log.Println(err)
+			return originLN, renderLN
+		}
+		occurDoc.lineNumMap.Store(renderLN, lineNum)
+		number := lineNum - m.firstLine() + 1
+		if occurDoc.global {
+			occurDoc.occurDocMap.Store(renderLN, m)
+			writeLine(occurDoc.w, globalOccurLine(line, m.FileName, number))
+		} else {
+			writeLine(occurDoc.w, occurLine(line, number, searcher))
+		}
+		renderLN++
+
+		originLN = lineNum + 1
+	}
+}
+
+// occurRankedWriter writes the matching lines to occurDoc in order of the score.
+// The lines are sorted after reading, so the lines added later are not listed.
+func (root *Root) occurRankedWriter(ctx context.Context, m *Document, searcher scorer, occurDoc *occurDocument) {
+	defer occurDoc.w.Close()
+	m.WaitEOFWithTimeout(root.Config.ReadWaitTime)
+	for renderLN, r := range m.occurRank(ctx, searcher) {
+		occurDoc.lineNumMap.Store(renderLN, r.lineNum)
+		writeLine(occurDoc.w, occurLine(r.line, r.lineNum-m.firstLine()+1, searcher))
+	}
+}
+
+// rankedLine is a line with the score of the match.
+type rankedLine struct {
+	lineNum int
+	score   int
+	line    []byte
+}
+
+// occurRank returns the matching lines sorted by the score (the same score in line order).
+func (m *Document) occurRank(ctx context.Context, searcher scorer) []rankedLine {
+	var lines []rankedLine
+	for lN := m.firstLine(); ; {
+		lineNum, err := m.searchLine(ctx, searcher, true, lN)
+		if err != nil {
+			break
+		}
+		line, err := m.Line(lineNum)
+		if err != nil {
+			log.Println(err)
+			break
+		}
+		score, _ := searcher.score(string(line))
+		lines = append(lines, rankedLine{lineNum: lineNum, score: score, line: line})
+		lN = lineNum + 1
+	}
+	slices.SortStableFunc(lines, func(a, b rankedLine) int {
+		return b.score - a.score
+	})
+	return lines
+}
+
+// occurLine returns the line prefixed with the line number, the column of the first match
+// and the number of matches.
+// The column is the number of characters from the beginning of the line (1-based).
+func occurLine(line []byte, number int, searcher Searcher) []byte {
+	target := string(line)
+	if _, ok := searcher.(rawWord); !ok {
+		target = stripEscapeSequenceString(target)
+	}
+	indexes := searcher.FindAll(target)
+	if len(indexes) == 0 {
+		return append([]byte(fmt.Sprintf("%6d (0): ", number)), line...)
+	}
+	column := utf8.RuneCountInString(target[:indexes[0][0]]) + 1
+	prefix := fmt.Sprintf("%6d:%d (%d): ", number, column, len(indexes))
+	return append([]byte(prefix), line...)
+}
+
+// occurJump moves to the line of the parent document
+// that corresponds to the current line of the occur document.
+func (root *Root) occurJump(ctx context.Context) {
+	m := root.Doc
+	current := root.scr.lineNumber(m.headerHeight + m.jumpTargetHeight)
+	lN, ok := m.lineNumMap.LoadForward(current.number)
+	if !ok {
+		root.setMessage("no line to jump")
+		return
+	}
+	target := m.parent
+	if doc, ok := m.occurDocMap.Load(current.number); ok {
+		target = doc.(*Document)
+	}
+	docNum := root.documentIndex(target)
+	if docNum < 0 {
+		root.setMessage("the original document is closed")
+		return
+	}
+	root.setDocumentNum(ctx, docNum)
+	if root.searcher == nil {
+		root.Doc.moveLine(lN - root.Doc.firstLine())
+		return
+	}
+	root.searchGo(ctx, lN, root.searcher)
+}
@@ -0,0 +1,209 @@
+package oviewer
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func occurDocHelper(t *testing.T, root *Root, searcher Searcher) (*Document, context.CancelFunc) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	root.occurDocument(ctx, searcher)
+	occurDoc := root.getDocument(root.DocumentLen() - 1)
+	for range 100 {
+		if occurDoc.BufEndNum() > 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return occurDoc, cancel
+}
+
+func Test_occurLine(t *testing.T) {
+	type args struct {
+		line     string
+		number   int
+		searcher Searcher
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "one",
+			args: args{
+				line:     "test",
+				number:   1,
+				searcher: NewSearcher("test", nil, false, false),
+			},
+			want: "     1:1 (1): test",
+		},
+		{
+			name: "two",
+			args: args{
+				line:     "test a Test",
+				number:   123,
+				searcher: NewSearcher("test", nil, false, false),
+			},
+			want: "   123:1 (2): test a Test",
+		},
+		{
+			name: "escape",
+			args: args{
+				line:     "\x1b[31mtest\x1b[m",
+				number:   10,
+				searcher: NewSearcher("test", nil, false, false),
+			},
+			want: "    10:1 (1): \x1b[31mtest\x1b[m",
+		},
+		{
+			name: "column",
+			args: args{
+				line:     "日本 a test",
+				number:   7,
+				searcher: NewSearcher("test", nil, false, false),
+			},
+			want: "     7:6 (1): 日本 a test",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(occurLine([]byte(tt.args.line), tt.args.number, tt.args.searcher)); got != tt.want {
+				t.Errorf("occurLine() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoot_occur(t *testing.T) {
+	tcellNewScreen = fakeScreen
+	defer func() {
+		tcellNewScreen = tcell.NewScreen
+	}()
+	root := rootFileReadHelper(t, filepath.Join(testdata, "test3.txt"))
+	root.occur(context.Background())
+	if root.DocumentLen() != 1 {
+		t.Errorf("occur() without search = %v, want %v", root.DocumentLen(), 1)
+	}
+}
+
+func TestRoot_occurDocument(t *testing.T) {
+	tcellNewScreen = fakeScreen
+	defer func() {
+		tcellNewScreen = tcell.NewScreen
+	}()
+	type fields struct {
+		fileNames []string
+		header    int
+	}
+	type args struct {
+		searcher Searcher
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		want     string
+		wantLine int
+	}{
+		{
+			name: "test3.txt",
+			fields: fields{
+				fileNames: []string{filepath.Join(testdata, "test3.txt")},
+				header:    0,
+			},
+			args: args{
+				searcher: NewSearcher("123", nil, false, false),
+			},
+			want:     "   123:1 (1): 123",
+			wantLine: 122,
+		},
+		{
+			name: "test3.txtHeader",
+			fields: fields{
+				fileNames: []string{filepath.Join(testdata, "test3.txt")},
+				header:    1,
+			},
+			args: args{
+				searcher: NewSearcher("9", nil, false, false),
+			},
+			want:     "     8:1 (1): 9",
+			wantLine: 8,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := rootFileReadHelper(t, tt.fields.fileNames...)
+			root.Doc.Header = tt.fields.header
+			occurDoc, cancel := occurDocHelper(t, root, tt.args.searcher)
+			defer cancel()
+			if occurDoc.documentType != DocOccur {
+				t.Errorf("occurDocument() documentType = %v, want %v", occurDoc.documentType, DocOccur)
+			}
+			line := occurDoc.getLineC(0)
+			if line.str != tt.want {
+				t.Errorf("occurDocument() = %q, want %q", line.str, tt.want)
+			}
+			if lN, ok := occurDoc.lineNumMap.LoadForward(0); !ok || lN != tt.wantLine {
+				t.Errorf("occurDocument() lineNumMap = %v, want %v", lN, tt.wantLine)
+			}
+		})
+	}
+}
+
+func TestRoot_occurJump(t *testing.T) {
+	tcellNewScreen = fakeScreen
+	defer func() {
+		tcellNewScreen = tcell.NewScreen
+	}()
+	root := rootFileReadHelper(t, filepath.Join(testdata, "test3.txt"))
+	root.prepareScreen()
+	searcher := root.setSearcher("123", false)
+	occurDoc, cancel := occurDocHelper(t, root, searcher)
+	defer cancel()
+	root.scr.numbers = []LineNumber{{number: 0}}
+	root.occurJump(context.Background())
+	if root.Doc == occurDoc {
+		t.Fatalf("occurJump() did not switch the document")
+	}
+	if root.Doc.lastSearchLN != 122 {
+		t.Errorf("occurJump() = %v, want %v", root.Doc.lastSearchLN, 122)
+	}
+}
+
+func TestRoot_occurJumpKey(t *testing.T) {
+	tcellNewScreen = fakeScreen
+	defer func() {
+		tcellNewScreen = tcell.NewScreen
+	}()
+	root := rootFileReadHelper(t, filepath.Join(testdata, "test3.txt"))
+	root.prepareScreen()
+	ctx := context.Background()
+	// The key of occur_jump is only used in the occur document.
+	keyBind := KeyBind{
+		actionMoveDown:  {"Enter"},
+		actionOccurJump: {"Enter"},
+	}
+	if duplicates, _ := findDuplicateKeyBind(keyBind); len(duplicates) != 0 {
+		t.Errorf("findDuplicateKeyBind() = %v, want no duplicates", duplicates)
+	}
+	if err := root.setHandlers(ctx, keyBind); err != nil {
+		t.Fatal(err)
+	}
+	searcher := root.setSearcher("123", false)
+	occurDoc, cancel := occurDocHelper(t, root, searcher)
+	defer cancel()
+	root.scr.numbers = []LineNumber{{number: 0}}
+	root.keyEvent(ctx, tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone))
+	if root.Doc == occurDoc {
+		t.Fatalf("keyEvent() did not jump from the occur document")
+	}
+	if root.Doc.lastSearchLN != 122 {
+		t.Errorf("keyEvent() = %v, want %v", root.Doc.lastSearchLN, 122)
+	}
+}
@@ -40,16 +40,23 @@ type Root struct {
 
 	// input contains the input mode.
 	input *Input
+	// history saves the input history to files (nil if disabled).
+	history *history
 	// cancelFunc saves the cancel function, which is a time-consuming process.
 	cancelFunc context.CancelFunc
 
 	// searcher is the search structure.
 	searcher Searcher
+	// searchSlots holds the searchers of the search slots.
+	searchSlots [searchSlotNum]Searcher
 
 	// keyConfig contains the binding settings for the key.
 	keyConfig *cbind.Configuration
 	// inputKeyConfig contains the binding settings for the key.
 	inputKeyConfig *cbind.Configuration
+	// occurKeyConfig contains the binding settings for the key in the occur document.
+	// They take precedence over keyConfig.
+	occurKeyConfig *cbind.Configuration
 
 	// Pattern is the search pattern.
 	Pattern string
@@ -205,6 +212,16 @@ type RunTimeSettings struct {
 	JumpTarget string
 	// MultiColorWords specifies words to color separated by spaces.
 	MultiColorWords []string
+	// LogfmtHideKeys is the keys not to display in the logfmt converter.
+	LogfmtHideKeys []string
+	// Parser is the name of the predefined parser or a regular expression with named groups.
+	Parser string
+	// SearchColumn is the column (number or header name) to which the search is limited.
+	SearchColumn string
+	// RegexpEngine is the regular expression engine ("pcre" for the backtracking engine).
+	RegexpEngine string
+	// TimeFormat is the format of the timestamp of the line used by goto time.
+	TimeFormat string
 
 	// TabWidth is tab stop num.
 	TabWidth int
@@ -245,6 +262,8 @@ type RunTimeSettings struct {
 	LineNumMode bool
 	// Wrap is Wrap mode.
 	WrapMode bool
+	// TableWrap wraps long cells within their column width in align mode.
+	TableWrap bool
 	// FollowMode is the follow mode.
 	FollowMode bool
 	// FollowAll is a follow mode for all documents.
@@ -282,6 +301,10 @@ type Style struct {
 	LineNumber OVStyle
 	// SearchHighlight is the style that applies to the search highlight.
 	SearchHighlight OVStyle
+	// SearchGroupHighlight is the style that applies to the capture groups of the regular expression search.
+	SearchGroupHighlight []OVStyle
+	// SearchSlotHighlight is the style that applies to each search slot.
+	SearchSlotHighlight []OVStyle
 	// ColumnHighlight is the style that applies to the column highlight.
 	ColumnHighlight OVStyle
 	// MarkLine is a style that marked line.
@@ -356,9 +379,11 @@ const MaxWriteLog int = 10
 
 // The name of the converter that can be specified.
 const (
-	convEscaped string = "es"    // convEscaped processes escape sequence(default).
-	convRaw     string = "raw"   // convRaw is displayed without processing escape sequences as they are.
-	convAlign   string = "align" // convAlign is aligned in each column.
+	convEscaped string = "es"     // convEscaped processes escape sequence(default).
+	convRaw     string = "raw"    // convRaw is displayed without processing escape sequences as they are.
+	convAlign   string = "align"  // convAlign is aligned in each column.
+	convLogfmt  string = "logfmt" // convLogfmt converts logfmt (key=value) into columns.
+	convParser  string = "parser" // convParser converts the named groups of the parser into columns.
 )
 
 const (
@@ -428,10 +453,26 @@ var (
 	ErrInvalidDocumentNum = errors.New("invalid document number")
 	// ErrInvalidModeName indicates that the specified view mode was not found.
 	ErrInvalidModeName = errors.New("view mode not found")
+	// ErrInvalidTableFormat indicates that the table format is not supported.
+	ErrInvalidTableFormat = errors.New("invalid table format")
+	// ErrSearchExprSyntax indicates that the search expression is invalid.
+	ErrSearchExprSyntax = errors.New("search expression syntax error")
+	// ErrSearchExprUnclosed indicates that the parenthesis or the quote of the search expression is not closed.
+	ErrSearchExprUnclosed = errors.New("unclosed search expression")
+	// ErrNoNamedGroup indicates that the parser has no named group.
+	ErrNoNamedGroup = errors.New("no named group in the parser")
 	// ErrInvalidRGBColor indicates that the RGB color is invalid.
 	ErrInvalidRGBColor = errors.New("invalid RGB color")
 	// ErrInvalidKey indicates that the key format is invalid.
 	ErrInvalidKey = errors.New("invalid key format")
+	// ErrNoEmptySearchSlot indicates that all the search slots are used.
+	ErrNoEmptySearchSlot = errors.New("no empty search slot")
+	// ErrInvalidTime indicates that the time cannot be parsed.
+	ErrInvalidTime = errors.New("invalid time")
+	// ErrNoTimestamp indicates that there is no line with a timestamp.
+	ErrNoTimestamp = errors.New("no timestamp")
+	// ErrHistoryLocked indicates that the history file is locked by another ov.
+	ErrHistoryLocked = errors.New("history is locked")
 )
 
 // This is a function of tcell.NewScreen but can be replaced with mock.
@@ -456,6 +497,7 @@ func NewOviewer(docs ...*Document) (*Root, error) {
 		Config:         NewConfig(),
 		keyConfig:      cbind.NewConfiguration(),
 		inputKeyConfig: cbind.NewConfiguration(),
+		occurKeyConfig: cbind.NewConfiguration(),
 		input:          NewInput(),
 	}
 	root.DocList = append(root.DocList, docs...)
@@ -507,6 +549,23 @@ func NewStyle() Style {
 		SearchHighlight: OVStyle{
 			Reverse: true,
 		},
+		SearchGroupHighlight: []OVStyle{
+			{Foreground: "aqua"},
+			{Foreground: "lime"},
+			{Foreground: "yellow"},
+			{Foreground: "fuchsia"},
+		},
+		SearchSlotHighlight: []OVStyle{
+			{Background: "darkred"},
+			{Background: "darkcyan"},
+			{Background: "olive"},
+			{Background: "darkmagenta"},
+			{Background: "darkgreen"},
+			{Background: "darkblue"},
+			{Background: "dimgray"},
+			{Background: "saddlebrown"},
+			{Background: "darkslategray"},
+		},
 		ColumnHighlight: OVStyle{
 			Reverse: true,
 		},
@@ -649,22 +708,11 @@ func openFiles(fileNames []string) (*Root, error) {
 
 // SetConfig sets config.
 func (root *Root) SetConfig(config Config) {
-	// Old Style* settings are loaded with lower priority.
-	root.settings = setOldStyle(root.settings, config)
-	// Old Prompt settings are loaded with lower priority.
-	root.settings = setOldPrompt(root.settings, config)
-	// General settings.
-	root.settings = updateRunTimeSettings(root.settings, config.General)
-
-	// view mode.
-	if config.ViewMode != "" {
-		viewMode, overwrite := config.Mode[config.ViewMode]
-		if overwrite {
-			root.settings = updateRunTimeSettings(root.settings, viewMode)
-		} else {
-			root.setMessageLogf("view mode not found: %s", config.ViewMode)
-		}
+	settings, ok := configSettings(root.settings, config)
+	if !ok {
+		root.setMessageLogf("view mode not found: %s", config.ViewMode)
 	}
+	root.settings = settings
 
 	// Set the follow mode for all documents.
 	root.FollowAll = root.settings.FollowAll
@@ -674,17 +722,40 @@ func (root *Root) SetConfig(config Config) {
 	if root.settings.Caption == "" {
 		root.settings.Caption = viper.GetString("CAPTION")
 	}
+	root.Config = config
+}
+
+// configSettings returns the RunTimeSettings updated by config.
+// Returns false if the view mode of config is not found.
+func configSettings(settings RunTimeSettings, config Config) (RunTimeSettings, bool) {
+	// Old Style* settings are loaded with lower priority.
+	settings = setOldStyle(settings, config)
+	// Old Prompt settings are loaded with lower priority.
+	settings = setOldPrompt(settings, config)
+	// General settings.
+	settings = updateRunTimeSettings(settings, config.General)
+
+	// view mode.
+	found := true
+	if config.ViewMode != "" {
+		viewMode, overwrite := lookupViewMode(config, config.ViewMode)
+		if overwrite {
+			settings = updateRunTimeSettings(settings, viewMode)
+		} else {
+			found = false
+		}
+	}
 
 	// Actually tabs when "\t" is specified as an option.
-	if root.settings.ColumnDelimiter == "\\t" {
-		root.settings.ColumnDelimiter = "\t"
+	if settings.ColumnDelimiter == "\\t" {
+		settings.ColumnDelimiter = "\t"
 	}
 
 	// SectionHeader is enabled if SectionHeaderNum is greater than 0.
-	if root.settings.SectionHeaderNum > 0 {
-		root.settings.SectionHeader = true
+	if settings.SectionHeaderNum > 0 {
+		settings.SectionHeader = true
 	}
-	root.Config = config
+	return settings, found
 }
 
 // SetWatcher sets file monitoring.
@@ -841,6 +912,7 @@ func (root *Root) prepareRun(ctx context.Context) error {
 	root.setCaption()
 
 	root.setViewModeConfig()
+	root.loadHistory()
 	root.prepareAllDocuments()
 	// follow mode or follow all disables quit if the output fits on one screen.
 	if root.Doc.FollowMode || root.FollowAll {
@@ -1090,6 +1162,9 @@ func updateRunTimeSettings(src RunTimeSettings, dst General) RunTimeSettings {
 	if dst.WrapMode != nil {
 		src.WrapMode = *dst.WrapMode
 	}
+	if dst.TableWrap != nil {
+		src.TableWrap = *dst.TableWrap
+	}
 	if dst.FollowMode != nil {
 		src.FollowMode = *dst.FollowMode
 	}
@@ -1126,6 +1201,21 @@ func updateRunTimeSettings(src RunTimeSettings, dst General) RunTimeSettings {
 	if dst.MultiColorWords != nil {
 		src.MultiColorWords = *dst.MultiColorWords
 	}
+	if dst.LogfmtHideKeys != nil {
+		src.LogfmtHideKeys = *dst.LogfmtHideKeys
+	}
+	if dst.Parser != nil {
+		src.Parser = *dst.Parser
+	}
+	if dst.SearchColumn != nil {
+		src.SearchColumn = *dst.SearchColumn
+	}
+	if dst.RegexpEngine != nil {
+		src.RegexpEngine = *dst.RegexpEngine
+	}
+	if dst.TimeFormat != nil {
+		src.TimeFormat = *dst.TimeFormat
+	}
 	if dst.Caption != nil {
 		src.Caption = *dst.Caption
 	}
@@ -1183,6 +1273,12 @@ func updateRuntimeStyle(src Style, dst StyleConfig) Style {
 	if dst.SearchHighlight != nil {
 		src.SearchHighlight = *dst.SearchHighlight
 	}
+	if dst.SearchGroupHighlight != nil {
+		src.SearchGroupHighlight = *dst.SearchGroupHighlight
+	}
+	if dst.SearchSlotHighlight != nil {
+		src.SearchSlotHighlight = *dst.SearchSlotHighlight
+	}
 	if dst.ColumnHighlight != nil {
 		src.ColumnHighlight = *dst.ColumnHighlight
 	}
@@ -1453,11 +1549,17 @@ func (root *Root) debugNumOfChunk() {
 
 // ListViewMode returns the list of view modes.
 func ListViewMode(config Config) []string {
-	list := make([]string, 0, len(config.Mode)+1)
+	list := make([]string, 0, len(config.Mode)+len(builtinParsers)+1)
 	list = append(list, nameGeneral)
 	for name := range config.Mode {
 		list = append(list, name)
 	}
+	// Predefined parsers that are not overwritten by the configuration file.
+	for _, name := range builtinParserNames() {
+		if _, ok := config.Mode[name]; !ok {
+			list = append(list, name)
+		}
+	}
 	sort.Strings(list)
 	return list
 }
@@ -822,7 +822,7 @@ func TestRoot_setViewModeConfig(t *testing.T) {
 					"view1": {},
 				},
 			},
-			wantList: []string{nameGeneral, "view1"},
+			wantList: []string{"combined", nameGeneral, "k8s", "rfc3164", "rfc5424", "view1"},
 		},
 	}
 	for _, tt := range tests {
@@ -1199,6 +1199,22 @@ func Test_updateRuntimeStyle(t *testing.T) {
 			},
 			want: Style{SearchHighlight: blueStyle},
 		},
+		{
+			name: "update search group highlight",
+			args: args{
+				src: Style{SearchGroupHighlight: multiColorStyles},
+				dst: StyleConfig{SearchGroupHighlight: &newMultiColorStyles},
+			},
+			want: Style{SearchGroupHighlight: newMultiColorStyles},
+		},
+		{
+			name: "update search slot highlight",
+			args: args{
+				src: Style{SearchSlotHighlight: multiColorStyles},
+				dst: StyleConfig{SearchSlotHighlight: &newMultiColorStyles},
+			},
+			want: Style{SearchSlotHighlight: newMultiColorStyles},
+		},
 		{
 			name: "update column highlight",
 			args: args{
@@ -1319,6 +1335,8 @@ func Test_updateRuntimeStyle(t *testing.T) {
 					Body:                 &blueStyle,
 					LineNumber:           &blueStyle,
 					SearchHighlight:      &blueStyle,
+					SearchGroupHighlight: &newMultiColorStyles,
+					SearchSlotHighlight:  &newMultiColorStyles,
 					ColumnHighlight:      &blueStyle,
 					MarkLine:             &blueStyle,
 					SectionLine:          &blueStyle,
@@ -1341,6 +1359,8 @@ func Test_updateRuntimeStyle(t *testing.T) {
 				Body:                 blueStyle,
 				LineNumber:           blueStyle,
 				SearchHighlight:      blueStyle,
+				SearchGroupHighlight: newMultiColorStyles,
+				SearchSlotHighlight:  newMultiColorStyles,
 				ColumnHighlight:      blueStyle,
 				MarkLine:             blueStyle,
 				SectionLine:          blueStyle,
@@ -34,6 +34,10 @@ func (root *Root) prepareScreen() {
 		root.scr.rulerHeight = rulerHeight
 	}
 	root.scr.startY = root.scr.rulerHeight
+	// The field converters (logfmt, parser) display the keys above the header.
+	if root.Doc.fieldConv() != nil {
+		root.scr.startY++
+	}
 
 	n, err := calculatePosition(root.Doc.HScrollWidth, root.scr.vWidth)
 	if err != nil {
@@ -106,7 +110,7 @@ func (root *Root) prepareDraw(ctx context.Context) {
 	root.scr.headerLN = root.Doc.SkipLines
 	root.scr.headerEnd = root.Doc.firstLine()
 	// Set the header height.
-	root.Doc.headerHeight = min(root.scr.vHeight, root.Doc.getHeight(root.scr.headerLN, root.scr.headerEnd)+root.scr.rulerHeight)
+	root.Doc.headerHeight = min(root.scr.vHeight, root.Doc.getHeight(root.scr.headerLN, root.scr.headerEnd)+root.scr.startY)
 
 	// Section header.
 	root.scr.sectionHeaderLN = -1
@@ -134,6 +138,10 @@ func (root *Root) prepareDraw(ctx context.Context) {
 	if root.Doc.Converter == convAlign {
 		root.setAlignConverter()
 	}
+	// Sets the field converter if the converter is logfmt or parser.
+	if conv := root.Doc.fieldConv(); conv != nil {
+		root.setFieldConverter(conv)
+	}
 	root.scr.bodyLN = root.Doc.topLN + root.Doc.firstLine()
 	root.scr.bodyEnd = root.scr.bodyLN + root.scr.vHeight // vHeight is the max line of logical lines.
 
@@ -187,9 +195,14 @@ func (root *Root) setAlignConverter() {
 		maxWidths, addRight = m.maxColumnWidths(maxWidths, addRight, ln)
 	}
 
-	if slices.Equal(m.alignConv.maxWidths, maxWidths) {
+	wrapWidth := 0
+	if m.WrapMode && m.TableWrap {
+		wrapWidth = m.width
+	}
+	if slices.Equal(m.alignConv.maxWidths, maxWidths) && m.alignConv.wrapWidth == wrapWidth {
 		return
 	}
+	m.alignConv.wrapWidth = wrapWidth
 	m.alignConv.orgWidths = m.columnWidths
 	m.alignConv.maxWidths = maxWidths
 	// column attributes are inherited, so only the required columns are added.
@@ -204,6 +217,48 @@ func (root *Root) setAlignConverter() {
 	m.ClearCache()
 }
 
+// setFieldConverter sets the keys and the maximum width of the field columns.
+func (root *Root) setFieldConverter(conv *fieldConverter) {
+	m := root.Doc
+
+	maxWidths := make([]int, 0, len(conv.maxWidths))
+	for ln := root.scr.headerLN; ln < root.scr.headerEnd; ln++ {
+		maxWidths = m.fieldColumnWidths(conv, maxWidths, ln)
+	}
+	for ln := root.scr.sectionHeaderLN; ln < root.scr.sectionHeaderEnd; ln++ {
+		maxWidths = m.fieldColumnWidths(conv, maxWidths, ln)
+	}
+	startLN := m.topLN + m.firstLine()
+	for ln := startLN; ln < startLN+root.scr.vHeight; ln++ {
+		maxWidths = m.fieldColumnWidths(conv, maxWidths, ln)
+	}
+	// The keys that do not appear in the lines have the width of the key.
+	maxWidths = conv.keyWidths(maxWidths, len(conv.keys)-1)
+
+	var hideKeys []string
+	if m.Converter == convLogfmt {
+		hideKeys = m.LogfmtHideKeys
+	}
+	if slices.Equal(conv.maxWidths, maxWidths) && slices.Equal(conv.hideKeys, hideKeys) {
+		return
+	}
+	conv.maxWidths = maxWidths
+	conv.hideKeys = slices.Clone(hideKeys)
+	m.ClearCache()
+}
+
+// fieldColumnWidths returns the maximum width of the field columns.
+func (m *Document) fieldColumnWidths(conv *fieldConverter, maxWidths []int, lN int) []int {
+	if lN < 0 {
+		return maxWidths
+	}
+	str, err := m.LineStr(lN)
+	if err != nil {
+		return maxWidths
+	}
+	return conv.columnWidths(maxWidths, StrToContents(str, m.TabWidth))
+}
+
 // maxColumnWidths returns the maximum width of the column.
 func (m *Document) maxColumnWidths(maxWidths []int, rightCount []int, lN int) ([]int, []int) {
 	if lN < 0 {
@@ -249,19 +304,20 @@ func maxWidthsWidth(lc contents, maxWidths []int, rightCount []int, widths []int
 	if len(widths) == 0 {
 		return maxWidths, rightCount
 	}
-	start := 0
+	start, lEnd := outerBorders(lc)
 	for i := range widths {
-		end := min(findColumnEnd(lc, widths, i, start)+1, len(lc))
+		end := min(findColumnEnd(lc, widths, i, start)+1, lEnd)
 		if start > end {
 			break
 		}
-		width, addRight := trimWidth(lc[start:end])
+		cEnd, _ := trimBorder(lc, start, end)
+		width, addRight := trimWidth(lc[start:cEnd])
 		maxWidths, rightCount = updateMaxWidths(maxWidths, rightCount, i, width, addRight)
 		start = end
 	}
 	// The last column.
-	if start < len(lc) {
-		width, addRight := trimWidth(lc[start:])
+	if start < lEnd {
+		width, addRight := trimWidth(lc[start:lEnd])
 		maxWidths, rightCount = updateMaxWidths(maxWidths, rightCount, len(widths), width, addRight)
 	}
 	return maxWidths, rightCount
@@ -422,6 +478,7 @@ func (root *Root) lineContent(lN int) LineC {
 	}
 	RangeStyle(lineC.lc, 0, len(lineC.lc), m.Style.Body)
 	root.styleContent(lineC)
+	root.rawSearchHighlight(lN, lineC)
 	return lineC
 }
 
@@ -435,6 +492,7 @@ func (root *Root) sectionNum(lines map[int]LineC) map[int]LineC {
 		log.Printf("Regular expression is not set: %s\n", m.SectionDelimiter)
 		return lines
 	}
+	searcher := m.sectionDelimiterSearcher()
 	lNs := lineNumbers(lines)
 	num := 1
 	section := 0
@@ -456,7 +514,7 @@ func (root *Root) sectionNum(lines map[int]LineC) map[int]LineC {
 				// section starts off screen.
 				sp = m.getLineC(lN - m.SectionStartPosition)
 			}
-			if m.SectionDelimiterReg.MatchString(sp.str) {
+			if searcher.MatchString(sp.str) {
 				num = 1
 				section++
 			}
@@ -491,6 +549,7 @@ func (root *Root) styleContent(lineC LineC) {
 		root.columnHighlight(lineC)
 	}
 	root.multiColorHighlight(lineC)
+	root.searchSlotHighlight(lineC)
 	root.searchHighlight(lineC)
 }
 
@@ -510,9 +569,9 @@ func (root *Root) columnHighlight(lineC LineC) {
 // The style of the first specified word takes precedence.
 func (root *Root) multiColorHighlight(lineC LineC) {
 	numC := len(root.Doc.Style.MultiColorHighlight)
-	for i := len(root.Doc.multiColorRegexps) - 1; i >= 0; i-- {
-		indexes := searchPositionReg(lineC.str, root.Doc.multiColorRegexps[i])
-		for _, idx := range indexes {
+	indexes := root.Doc.multiColor.findAll(lineC.str)
+	for i := len(indexes) - 1; i >= 0; i-- {
+		for _, idx := range indexes[i] {
 			RangeStyle(lineC.lc, lineC.pos.x(idx[0]), lineC.pos.x(idx[1]), root.Doc.Style.MultiColorHighlight[i%numC])
 		}
 	}
@@ -524,18 +583,45 @@ func (root *Root) searchHighlight(lineC LineC) {
 	if root.searcher == nil || root.searcher.String() == "" {
 		return
 	}
+	// The raw search is highlighted by rawSearchHighlight.
+	if _, ok := root.searcher.(rawWord); ok {
+		return
+	}
 
 	indexes := root.searchPosition(lineC.str)
 	for _, idx := range indexes {
 		RangeStyle(lineC.lc, lineC.pos.x(idx[0]), lineC.pos.x(idx[1]), root.Doc.Style.SearchHighlight)
 	}
+	root.searchGroupHighlight(lineC)
+}
+
+// searchGroupHighlight applies the style of each capture group of the regular expression search.
+// Inner groups take precedence over outer groups.
+func (root *Root) searchGroupHighlight(lineC LineC) {
+	styles := root.Doc.Style.SearchGroupHighlight
+	finder, ok := root.searcher.(groupFinder)
+	if !ok || len(styles) == 0 {
+		return
+	}
+	for _, idx := range finder.FindAllGroups(lineC.str) {
+		for g := 1; g*2+1 < len(idx); g++ {
+			start, end := idx[g*2], idx[g*2+1]
+			if start < 0 {
+				continue
+			}
+			RangeStyle(lineC.lc, lineC.pos.x(start), lineC.pos.x(end), styles[(g-1)%len(styles)])
+		}
+	}
 }
 
 // columnRanges sets the column ranges.
 func (m *Document) columnRanges(lineC LineC) LineC {
-	if m.ColumnWidth {
+	switch {
+	case m.fieldConv() != nil:
+		lineC.columnRanges = delimiterRanges(lineC, fieldDelimiter, nil)
+	case m.ColumnWidth:
 		lineC.columnRanges = m.columnWidthRanges(lineC)
-	} else {
+	default:
 		lineC.columnRanges = m.columnDelimiterRange(lineC)
 	}
 	return lineC
@@ -543,7 +629,12 @@ func (m *Document) columnRanges(lineC LineC) LineC {
 
 // columnDelimiterRange returns the ranges of the columns.
 func (m *Document) columnDelimiterRange(lineC LineC) []columnRange {
-	indexes := allIndex(lineC.str, m.ColumnDelimiter, m.ColumnDelimiterReg)
+	return delimiterRanges(lineC, m.ColumnDelimiter, m.ColumnDelimiterReg)
+}
+
+// delimiterRanges returns the ranges of the columns separated by the delimiter.
+func delimiterRanges(lineC LineC, delimiter string, delimiterReg *regexp.Regexp) []columnRange {
+	indexes := allIndex(lineC.str, delimiter, delimiterReg)
 	if len(indexes) == 0 {
 		return nil
 	}
@@ -572,13 +663,16 @@ func (m *Document) columnWidthRanges(lineC LineC) []columnRange {
 	}
 
 	var columnRanges []columnRange
-	start, end := 0, 0
+	// The outer borders of the table are not included in the columns.
+	start, lEnd := outerBorders(lineC.lc)
+	end := 0
 	for c := range len(indexes) + 1 {
 		if m.Converter == convAlign {
 			end = alignColumnEnd(lineC.lc, m.alignConv.maxWidths, c, start)
 		} else {
 			end = findColumnEnd(lineC.lc, indexes, c, start)
 		}
+		end = min(end, lEnd)
 		if start > end {
 			break
 		}
@@ -616,8 +710,8 @@ func findColumnEnd(lc contents, indexes []int, n int, start int) int {
 		return len(lc)
 	}
 
-	// If the character at the end of the column is a space, return the end of the column.
-	if lc.IsSpace(columnEnd) {
+	// If the character at the end of the column is a space or a border, return the end of the column.
+	if lc.IsSpace(columnEnd) || isColumnBorder(lc[columnEnd].mainc) {
 		return columnEnd
 	}
 
@@ -655,6 +655,73 @@ func TestRoot_searchHighlight(t *testing.T) {
 	}
 }
 
+func TestRoot_searchGroupHighlight(t *testing.T) {
+	tcellNewScreen = fakeScreen
+	defer func() {
+		tcellNewScreen = tcell.NewScreen
+	}()
+	searchHighlight := tcell.StyleDefault.Reverse(true)
+	group1 := searchHighlight.Foreground(tcell.GetColor("aqua"))
+	group2 := searchHighlight.Foreground(tcell.GetColor("lime"))
+	tests := []struct {
+		name     string
+		searcher Searcher
+		want     []tcell.Style
+	}{
+		{
+			name:     "groups",
+			searcher: NewSearcher(`user=(\w+) st=(\d+)`, regexpCompile(`user=(\w+) st=(\d+)`, false), false, true),
+			want: []tcell.Style{
+				searchHighlight, searchHighlight, searchHighlight, searchHighlight, searchHighlight,
+				group1, group1,
+				searchHighlight, searchHighlight, searchHighlight, searchHighlight,
+				group2, group2, group2,
+				tcell.StyleDefault,
+			},
+		},
+		{
+			name:     "optionalGroup",
+			searcher: NewSearcher(`(x)?st=(\d+)`, regexpCompile(`(x)?st=(\d+)`, false), false, true),
+			want: []tcell.Style{
+				tcell.StyleDefault, tcell.StyleDefault, tcell.StyleDefault, tcell.StyleDefault, tcell.StyleDefault,
+				tcell.StyleDefault, tcell.StyleDefault, tcell.StyleDefault,
+				searchHighlight, searchHighlight, searchHighlight,
+				group2, group2, group2,
+				tcell.StyleDefault,
+			},
+		},
+		{
+			name:     "notRegexp",
+			searcher: NewSearcher("ab", nil, false, false),
+			want: []tcell.Style{
+				tcell.StyleDefault, tcell.StyleDefault, tcell.StyleDefault, tcell.StyleDefault, tcell.StyleDefault,
+				searchHighlight, searchHighlight,
+				tcell.StyleDefault, tcell.StyleDefault, tcell.StyleDefault, tcell.StyleDefault,
+				tcell.StyleDefault, tcell.StyleDefault, tcell.StyleDefault,
+				tcell.StyleDefault,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, err := NewRoot(bytes.NewBufferString("user=ab st=200 \n"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			root.Doc.WaitEOF()
+			root.Doc.width = 80
+			lineC := root.Doc.getLineC(0)
+			root.searcher = tt.searcher
+			root.searchHighlight(lineC)
+			for i, want := range tt.want {
+				if got := lineC.lc[i].style; got != want {
+					t.Errorf("style[%d] = %v, want %v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
 func TestRoot_columnDelimiterHighlight(t *testing.T) {
 	tcellNewScreen = fakeScreen
 	columnHighlight := tcell.StyleDefault.Bold(true)
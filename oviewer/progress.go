@@ -0,0 +1,215 @@
+package oviewer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// progressInterval is the interval to display the progress of a long-running operation.
+const progressInterval = 500 * time.Millisecond
+
+// searchProgress is the progress of an operation that scans the chunks of a document,
+// such as search and filter.
+// Each operation has its own progress, which is passed to the search functions in the context.
+type searchProgress struct {
+	mu sync.Mutex
+	// running is true while the operation is running.
+	running bool
+	// doc is the document being scanned.
+	doc *Document
+	// startTime is the time when the operation started.
+	startTime time.Time
+	// startChunk is the chunk where the operation started.
+	startChunk int
+	// endChunk is the last chunk to scan backward.
+	// Forward scanning continues to the last chunk of the document, which may increase.
+	endChunk int
+	// forward is true if the chunks are scanned forward.
+	forward bool
+	// chunk is the chunk being scanned.
+	chunk int
+}
+
+// progressKey is the context key of the progress of the operation.
+type progressKey struct{}
+
+// withProgress returns the context that carries the progress of the operation.
+func withProgress(ctx context.Context, p *searchProgress) context.Context {
+	return context.WithValue(ctx, progressKey{}, p)
+}
+
+// progressFromContext returns the progress of the operation, or nil if there is none.
+func progressFromContext(ctx context.Context) *searchProgress {
+	p, _ := ctx.Value(progressKey{}).(*searchProgress)
+	return p
+}
+
+// begin starts the progress of scanning doc from startChunk to endChunk.
+// It returns false if the operation is already in progress (the search is nested),
+// in which case the chunks are counted as the progress of the outer search.
+func (p *searchProgress) begin(doc *Document, startChunk int, endChunk int, forward bool) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.running {
+		return false
+	}
+	p.running = true
+	p.doc = doc
+	p.startTime = time.Now()
+	p.startChunk = startChunk
+	p.endChunk = endChunk
+	p.forward = forward
+	p.chunk = startChunk
+	return true
+}
+
+// end ends the progress.
+func (p *searchProgress) end() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.running = false
+}
+
+// update sets the chunk being scanned.
+// It does nothing if p is nil.
+func (p *searchProgress) update(chunkNum int) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.running {
+		return
+	}
+	if (p.forward && chunkNum > p.chunk) || (!p.forward && chunkNum < p.chunk) {
+		p.chunk = chunkNum
+	}
+}
+
+// status returns the percentage of the chunks scanned, lines per second and the estimated time remaining.
+// lastChunk is the current last chunk of the document.
+// It returns false if no operation is in progress.
+func (p *searchProgress) status(lastChunk int) (string, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.running {
+		return "", false
+	}
+	done := p.chunk - p.startChunk
+	total := max(lastChunk, p.chunk) - p.startChunk + 1
+	if !p.forward {
+		done = p.startChunk - p.chunk
+		total = p.startChunk - min(p.endChunk, p.chunk) + 1
+	}
+	return progressStatus(done, total, time.Since(p.startTime)), true
+}
+
+// progressStatus returns the status of done chunks out of total chunks in elapsed time.
+func progressStatus(done int, total int, elapsed time.Duration) string {
+	var s strings.Builder
+	fmt.Fprintf(&s, "%d%%", done*100/max(total, 1))
+	if done == 0 || elapsed <= 0 {
+		return s.String()
+	}
+	lines := float64(done*ChunkSize) / elapsed.Seconds()
+	fmt.Fprintf(&s, " %s lines/s", humanCount(lines))
+	remaining := time.Duration(float64(elapsed) * float64(total-done) / float64(done))
+	fmt.Fprintf(&s, " ETA %s", remaining.Round(time.Second))
+	return s.String()
+}
+
+// humanCount returns the number with a unit (k, M, G).
+func humanCount(n float64) string {
+	switch {
+	case n >= 1e9:
+		return fmt.Sprintf("%.1fG", n/1e9)
+	case n >= 1e6:
+		return fmt.Sprintf("%.1fM", n/1e6)
+	case n >= 1e3:
+		return fmt.Sprintf("%.1fk", n/1e3)
+	}
+	return fmt.Sprintf("%.0f", n)
+}
+
+// docStatus returns the status of the progress with the current last chunk of the document.
+func (p *searchProgress) docStatus() (string, bool) {
+	p.mu.Lock()
+	doc := p.doc
+	p.mu.Unlock()
+	if doc == nil {
+		return "", false
+	}
+	return p.status(doc.store.lastChunkNum())
+}
+
+// startProgress starts the progress of the operation in ctx, scanning from startChunk toward endChunk.
+// The returned function ends the progress.
+// If the operation is already in progress, its progress is continued.
+func (m *Document) startProgress(ctx context.Context, startChunk int, endChunk int, forward bool) func() {
+	p := progressFromContext(ctx)
+	if p == nil || !p.begin(m, startChunk, endChunk, forward) {
+		return func() {}
+	}
+	return p.end
+}
+
+// eventProgress represents the progress display event.
+type eventProgress struct {
+	p *searchProgress
+	tcell.EventTime
+	msg string
+}
+
+// progressTicker posts the progress p of the operation with msg at regular intervals.
+// The returned function stops it, and the message is restored to msg
+// if the progress has been displayed.
+// Nothing is displayed for an operation that ends within progressInterval.
+func (root *Root) progressTicker(p *searchProgress, msg string) func() {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(progressInterval)
+		defer ticker.Stop()
+		shown := false
+		for {
+			select {
+			case <-done:
+				if shown {
+					root.postProgress(p, msg)
+				}
+				return
+			case <-ticker.C:
+				root.postProgress(p, msg)
+				shown = true
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+// postProgress posts the progress event.
+func (root *Root) postProgress(p *searchProgress, msg string) {
+	ev := &eventProgress{p: p, msg: msg}
+	ev.SetEventNow()
+	root.postEvent(ev)
+}
+
+// showProgress displays the progress p of the operation in the status line.
+// The status line shows the progress of the active operation, that is,
+// the operation whose message is displayed.
+// Other messages, including the messages of other operations, are not overwritten.
+func (root *Root) showProgress(p *searchProgress, msg string) {
+	if root.message != msg && !strings.HasPrefix(root.message, msg+" ") {
+		return
+	}
+	status, ok := p.docStatus()
+	if !ok {
+		root.setMessage(msg)
+		return
+	}
+	root.setMessage(msg + " " + status)
+}
@@ -0,0 +1,117 @@
+package oviewer
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func Test_progressStatus(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		done    int
+		total   int
+		elapsed time.Duration
+		want    string
+	}{
+		{name: "start", done: 0, total: 10, elapsed: time.Second, want: "0%"},
+		{name: "half", done: 5, total: 10, elapsed: 5 * time.Second, want: "50% 10.0k lines/s ETA 5s"},
+		{name: "fast", done: 300, total: 400, elapsed: time.Second, want: "75% 3.0M lines/s ETA 0s"},
+		{name: "noTotal", done: 0, total: 0, elapsed: 0, want: "0%"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := progressStatus(tt.done, tt.total, tt.elapsed); got != tt.want {
+				t.Errorf("progressStatus() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_searchProgress(t *testing.T) {
+	t.Parallel()
+	var p searchProgress
+	if _, ok := p.status(10); ok {
+		t.Fatal("status() ok = true before begin")
+	}
+	if !p.begin(nil, 2, 0, true) {
+		t.Fatal("begin() = false")
+	}
+	if p.begin(nil, 0, 0, true) {
+		t.Error("begin() = true while running")
+	}
+	p.update(6)
+	// Chunks already passed do not go back.
+	p.update(3)
+	got, ok := p.status(11)
+	if !ok {
+		t.Fatal("status() ok = false")
+	}
+	if !strings.HasPrefix(got, "40%") {
+		t.Errorf("status() = %v, want 40%%", got)
+	}
+	p.end()
+	if _, ok := p.status(11); ok {
+		t.Error("status() ok = true after end")
+	}
+
+	// Backward.
+	p.begin(nil, 9, 5, false)
+	p.update(7)
+	if got, _ := p.status(20); !strings.HasPrefix(got, "40%") {
+		t.Errorf("status() = %v, want 40%%", got)
+	}
+}
+
+func TestRoot_showProgress(t *testing.T) {
+	tcellNewScreen = fakeScreen
+	defer func() {
+		tcellNewScreen = tcell.NewScreen
+	}()
+	root, err := NewRoot(bytes.NewBufferString("a\nb\nc\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	root.Doc.WaitEOF()
+	root.prepareScreen()
+
+	ctx := withProgress(context.Background(), &searchProgress{})
+	stop := root.Doc.startProgress(ctx, 0, 0, true)
+	p := progressFromContext(ctx)
+	root.setMessage("search:a")
+	root.showProgress(p, "search:a")
+	if !strings.HasPrefix(root.message, "search:a 0%") {
+		t.Errorf("message = %v, want progress", root.message)
+	}
+
+	// Another operation has its own progress,
+	// and does not overwrite the status line of the active operation.
+	filterCtx := withProgress(context.Background(), &searchProgress{})
+	stopFilter := root.Doc.startProgress(filterCtx, 0, 0, true)
+	if _, ok := progressFromContext(filterCtx).docStatus(); !ok {
+		t.Error("docStatus() ok = false, want the progress of the other operation")
+	}
+	root.showProgress(progressFromContext(filterCtx), "filter:b")
+	if !strings.HasPrefix(root.message, "search:a 0%") {
+		t.Errorf("message = %v, want search progress", root.message)
+	}
+	stopFilter()
+
+	stop()
+	root.showProgress(p, "search:a")
+	if root.message != "search:a" {
+		t.Errorf("message = %v, want search:a", root.message)
+	}
+	// Other messages are not overwritten.
+	root.setMessage("other")
+	root.showProgress(p, "search:a")
+	if root.message != "other" {
+		t.Errorf("message = %v, want other", root.message)
+	}
+}
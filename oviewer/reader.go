@@ -384,6 +384,8 @@ func (m *Document) reset() {
 	m.store.setNewLoadChunks(m.memoryLimit)
 	atomic.StoreInt32(&m.store.changed, 1)
 	m.ClearCache()
+	m.cancelMatchCount()
+	m.cancelSearchIndex()
 }
 
 // checkClose returns if the file is closed.
@@ -0,0 +1,208 @@
+package oviewer
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+
+	"github.com/dlclark/regexp2"
+	"github.com/gdamore/tcell/v2"
+)
+
+// regexpEnginePCRE is the name of the backtracking engine that supports lookaround and backreferences.
+// The default engine is the regexp package (RE2 syntax).
+const regexpEnginePCRE = "pcre"
+
+// backtrackPrefix is the prefix of the word to use the backtracking engine regardless of the setting.
+const backtrackPrefix = "(?pcre)"
+
+// backtrackTimeout is the time limit for matching a line with the backtracking engine.
+// Backtracking can take exponential time, so a match that takes longer is treated as no match
+// and reported after the search.
+const backtrackTimeout = 100 * time.Millisecond
+
+// backtrackCompile compiles the regular expression with the backtracking engine.
+func backtrackCompile(pattern string, caseSensitive bool) (*regexp2.Regexp, error) {
+	opt := regexp2.None
+	if !caseSensitive {
+		opt = regexp2.IgnoreCase
+	}
+	re, err := regexp2.Compile(pattern, opt)
+	if err != nil {
+		return nil, err
+	}
+	re.MatchTimeout = backtrackTimeout
+	return re, nil
+}
+
+// backtrackWord is a regular expression search with the backtracking engine.
+type backtrackWord struct {
+	word   string
+	regexp *regexp2.Regexp
+	// caseSensitive is the case sensitivity of regexp.
+	caseSensitive bool
+	// timeouts is the number of the matches that timed out.
+	timeouts *atomic.Int64
+}
+
+// newBacktrackWord returns the backtrackWord of the pattern.
+// word is the original search word (including the prefix).
+func newBacktrackWord(word string, pattern string, caseSensitive bool) (backtrackWord, error) {
+	re, err := backtrackCompile(pattern, caseSensitive)
+	if err != nil {
+		return backtrackWord{}, err
+	}
+	return backtrackWord{word: word, regexp: re, caseSensitive: caseSensitive, timeouts: &atomic.Int64{}}, nil
+}
+
+// backtrackWord Match is a regular expression search for bytes.
+func (substr backtrackWord) Match(target []byte) bool {
+	return substr.MatchString(string(target))
+}
+
+// backtrackWord MatchString is a regular expression search for string.
+func (substr backtrackWord) MatchString(target string) bool {
+	target = stripEscapeSequenceString(target)
+	ok, err := substr.regexp.MatchString(target)
+	if err != nil {
+		substr.timeouts.Add(1)
+		return false
+	}
+	return ok
+}
+
+// backtrackWord FindAll searches for strings and returns the index of the match.
+func (substr backtrackWord) FindAll(target string) [][]int {
+	var indexes [][]int
+	backtrackFindAll(substr.regexp, substr.timeouts, target, func(m *regexp2.Match, offsets []int) {
+		indexes = append(indexes, []int{offsets[m.Index], offsets[m.Index+m.Length]})
+	})
+	return indexes
+}
+
+// backtrackWord FindAllGroups searches for strings and returns the index of the match and its capture groups.
+func (substr backtrackWord) FindAllGroups(target string) [][]int {
+	var indexes [][]int
+	backtrackFindAll(substr.regexp, substr.timeouts, target, func(m *regexp2.Match, offsets []int) {
+		groups := m.Groups()
+		idx := make([]int, 0, len(groups)*2)
+		for _, g := range groups {
+			if len(g.Captures) == 0 {
+				idx = append(idx, -1, -1)
+				continue
+			}
+			idx = append(idx, offsets[g.Index], offsets[g.Index+g.Length])
+		}
+		indexes = append(indexes, idx)
+	})
+	return indexes
+}
+
+// backtrackWord String returns the search word.
+func (substr backtrackWord) String() string {
+	return substr.word
+}
+
+// backtrackFindAll calls fn for each match of re in s.
+// offsets converts the rune positions of the match to the byte positions of s.
+// A timeout is counted in timeouts.
+func backtrackFindAll(re *regexp2.Regexp, timeouts *atomic.Int64, s string, fn func(m *regexp2.Match, offsets []int)) {
+	m, err := re.FindStringMatch(s)
+	if err != nil || m == nil {
+		logBacktrackError(timeouts, err)
+		return
+	}
+	offsets := runeOffsets(s)
+	for m != nil {
+		fn(m, offsets)
+		m, err = re.FindNextMatch(m)
+		if err != nil {
+			logBacktrackError(timeouts, err)
+			return
+		}
+	}
+}
+
+// runeOffsets returns the byte position of each rune in s, and len(s) at the end.
+// An invalid UTF-8 byte is a rune, as in the conversion of string to []rune.
+func runeOffsets(s string) []int {
+	offsets := make([]int, 0, len(s)+1)
+	for i := 0; i < len(s); {
+		offsets = append(offsets, i)
+		_, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+	}
+	return append(offsets, len(s))
+}
+
+// logBacktrackError logs the error of the backtracking engine, such as timeout, and counts it.
+func logBacktrackError(timeouts *atomic.Int64, err error) {
+	if err != nil {
+		timeouts.Add(1)
+		log.Printf("regexp: %v", err)
+	}
+}
+
+// backtrackTimeouts returns the number of the matches of the searcher that timed out.
+func backtrackTimeouts(searcher Searcher) int64 {
+	switch s := searcher.(type) {
+	case backtrackWord:
+		return s.timeouts.Load()
+	case normalizedWord:
+		return backtrackTimeouts(s.searcher)
+	case columnSearcher:
+		return backtrackTimeouts(s.Searcher)
+	case slotSearcher:
+		var n int64
+		for _, slot := range s.searchers {
+			n += backtrackTimeouts(slot)
+		}
+		return n
+	}
+	return 0
+}
+
+// backtrackTimeoutNote returns the note to add to the search message
+// if the matches of the searcher timed out since before.
+// It returns an empty string if there was no timeout.
+func backtrackTimeoutNote(searcher Searcher, before int64) string {
+	n := backtrackTimeouts(searcher) - before
+	if n <= 0 {
+		return ""
+	}
+	return fmt.Sprintf(": regexp timed out in %d lines (treated as not matching)", n)
+}
+
+// eventBacktrackTimeout represents the event to report the timeout of the backtracking engine.
+type eventBacktrackTimeout struct {
+	tcell.EventTime
+	msg string
+}
+
+// sendBacktrackTimeout fires the eventBacktrackTimeout event.
+func (root *Root) sendBacktrackTimeout(msg string) {
+	ev := &eventBacktrackTimeout{msg: msg}
+	ev.SetEventNow()
+	root.postEvent(ev)
+}
+
+// newRegexpSearcher returns the Searcher of the regular expression with the backtracking engine
+// if the engine is "pcre" or the word has the backtrackPrefix.
+// It returns nil if the word should be searched by the regexp package,
+// such as a word without special characters or a pattern that cannot be compiled.
+func newRegexpSearcher(word string, engine string, caseSensitive bool) Searcher {
+	pattern, prefixed := strings.CutPrefix(word, backtrackPrefix)
+	if !prefixed && (!strings.EqualFold(engine, regexpEnginePCRE) || pattern == regexp.QuoteMeta(pattern)) {
+		return nil
+	}
+	searcher, err := newBacktrackWord(word, pattern, caseSensitive)
+	if err != nil {
+		log.Printf("regexp %s: %v", pattern, err)
+		return nil
+	}
+	return searcher
+}
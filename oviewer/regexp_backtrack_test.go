@@ -0,0 +1,198 @@
+package oviewer
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func Test_newRegexpSearcher(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		word   string
+		engine string
+		want   bool
+	}{
+		{name: "re2", word: "a.*b", engine: "", want: false},
+		{name: "pcre", word: "a.*b", engine: "pcre", want: true},
+		{name: "pcreUpper", word: "a.*b", engine: "PCRE", want: true},
+		{name: "pcreLiteral", word: "error", engine: "pcre", want: false},
+		{name: "prefix", word: "(?pcre)error", engine: "", want: true},
+		{name: "invalid", word: "(?pcre)a(", engine: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := newRegexpSearcher(tt.word, tt.engine, true); (got != nil) != tt.want {
+				t.Errorf("newRegexpSearcher() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_backtrackWord(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		word          string
+		caseSensitive bool
+		target        string
+		wantMatch     bool
+		want          [][]int
+		wantGroups    [][]int
+	}{
+		{
+			name:       "negativeLookahead",
+			word:       "(?pcre)ERROR(?!.*retrying)",
+			target:     "ERROR timeout",
+			wantMatch:  true,
+			want:       [][]int{{0, 5}},
+			wantGroups: [][]int{{0, 5}},
+		},
+		{
+			name:      "negativeLookaheadNoMatch",
+			word:      "(?pcre)ERROR(?!.*retrying)",
+			target:    "ERROR timeout, retrying",
+			wantMatch: false,
+		},
+		{
+			name:       "lookbehind",
+			word:       "(?pcre)(?<=\\bid=)\\d+",
+			target:     "user id=42 pid=7",
+			wantMatch:  true,
+			want:       [][]int{{8, 10}},
+			wantGroups: [][]int{{8, 10}},
+		},
+		{
+			name:       "backreference",
+			word:       "(?pcre)(\\w+) \\1",
+			target:     "日本 the the end",
+			wantMatch:  true,
+			want:       [][]int{{7, 14}},
+			wantGroups: [][]int{{7, 14, 7, 10}},
+		},
+		{
+			name:       "caseInsensitive",
+			word:       "(?pcre)error",
+			target:     "Error error",
+			wantMatch:  true,
+			want:       [][]int{{0, 5}, {6, 11}},
+			wantGroups: [][]int{{0, 5}, {6, 11}},
+		},
+		{
+			name:          "caseSensitive",
+			word:          "(?pcre)error",
+			caseSensitive: true,
+			target:        "Error",
+			wantMatch:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s := newRegexpSearcher(tt.word, "", tt.caseSensitive)
+			if s == nil {
+				t.Fatal("newRegexpSearcher() = nil")
+			}
+			if got := s.Match([]byte(tt.target)); got != tt.wantMatch {
+				t.Errorf("Match() = %v, want %v", got, tt.wantMatch)
+			}
+			if got := s.FindAll(tt.target); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindAll() = %v, want %v", got, tt.want)
+			}
+			if got := s.(groupFinder).FindAllGroups(tt.target); !reflect.DeepEqual(got, tt.wantGroups) {
+				t.Errorf("FindAllGroups() = %v, want %v", got, tt.wantGroups)
+			}
+			if got := s.String(); got != tt.word {
+				t.Errorf("String() = %v, want %v", got, tt.word)
+			}
+		})
+	}
+}
+
+func Test_backtrackWord_timeout(t *testing.T) {
+	t.Parallel()
+	s := newRegexpSearcher("(?pcre)^(a+)+$", "", true)
+	target := string(bytes.Repeat([]byte("a"), 64)) + "b"
+	if s.MatchString(target) {
+		t.Errorf("MatchString() = true, want false")
+	}
+	if got := backtrackTimeouts(s); got != 1 {
+		t.Errorf("backtrackTimeouts() = %d, want 1", got)
+	}
+	if got := s.FindAll(target); got != nil {
+		t.Errorf("FindAll() = %v, want nil", got)
+	}
+	if got := backtrackTimeouts(s); got != 2 {
+		t.Errorf("backtrackTimeouts() = %d, want 2", got)
+	}
+	if got := backtrackTimeoutNote(s, 2); got != "" {
+		t.Errorf("backtrackTimeoutNote() = %q, want empty", got)
+	}
+}
+
+func TestRoot_searchMoveBacktrackTimeout(t *testing.T) {
+	tcellNewScreen = fakeScreen
+	defer func() {
+		tcellNewScreen = tcell.NewScreen
+	}()
+	root, err := NewRoot(bytes.NewBufferString("ok\n" + string(bytes.Repeat([]byte("a"), 64)) + "b\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	root.Doc.WaitEOF()
+	root.prepareScreen()
+	searcher := newRegexpSearcher("(?pcre)^(a+)+$", "", true)
+	if root.searchMove(context.Background(), true, 0, searcher) {
+		t.Errorf("searchMove() = true, want false")
+	}
+	want := ": regexp timed out in 1 lines (treated as not matching)"
+	if !strings.HasSuffix(root.message, want) {
+		t.Errorf("message = %q, want suffix %q", root.message, want)
+	}
+}
+
+func TestRoot_regexpEngine(t *testing.T) {
+	tcellNewScreen = fakeScreen
+	defer func() {
+		tcellNewScreen = tcell.NewScreen
+	}()
+	root, err := NewRoot(bytes.NewBufferString("# a\nERROR retrying\n## b\nERROR failed\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	root.Doc.WaitEOF()
+	root.prepareScreen()
+	root.Doc.RegexpEngine = "pcre"
+	root.Config.RegexpSearch = true
+
+	searcher := root.newSearcher("ERROR(?!.*retrying)", true)
+	if _, ok := searcher.(backtrackWord); !ok {
+		t.Fatalf("newSearcher() = %T, want backtrackWord", searcher)
+	}
+	lN, err := root.Doc.SearchLine(context.Background(), searcher, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lN != 3 {
+		t.Errorf("SearchLine() = %d, want 3", lN)
+	}
+
+	// Section delimiter.
+	root.Doc.setSectionDelimiter("^#(?!#)")
+	lN, err = root.Doc.nextSection(context.Background(), 0)
+	if err == nil {
+		t.Errorf("nextSection() = %d, want no section", lN)
+	}
+
+	// Multi color.
+	root.Doc.setMultiColorWords([]string{"(?<=ERROR )\\w+"})
+	if got, want := root.Doc.multiColor.findAll("ERROR failed"), [][][]int{{{6, 12}}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("findAll() = %v, want %v", got, want)
+	}
+}
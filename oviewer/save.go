@@ -22,8 +22,10 @@ const (
 )
 
 // saveBuffer saves the buffer to the specified file.
+// If the file name has a table format prefix (such as "csv:file.csv"),
+// the table view is exported in that format.
 func (root *Root) saveBuffer(input string) {
-	fileName := strings.TrimSpace(input)
+	format, fileName := splitTableFormat(strings.TrimSpace(input))
 
 	flag, err := root.promptSaveFlag(fileName)
 	if err != nil {
@@ -38,6 +40,15 @@ func (root *Root) saveBuffer(input string) {
 	}
 	defer file.Close()
 
+	if format != "" {
+		if err := root.Doc.ExportTable(file, format); err != nil {
+			root.setMessageLogf("cannot save: %s:%s", fileName, err)
+			return
+		}
+		root.setMessageLogf("saved %s (%s)", fileName, format)
+		return
+	}
+
 	if err := root.Doc.Export(file, root.Doc.BufStartNum(), root.Doc.BufEndNum()); err != nil {
 		root.setMessageLogf("cannot save: %s:%s", fileName, err)
 		return
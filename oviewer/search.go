@@ -32,6 +32,12 @@ type Searcher interface {
 	String() string
 }
 
+// groupFinder is a Searcher that can return the positions of the capture groups.
+type groupFinder interface {
+	// FindAllGroups searches for strings and returns the index of the match and its capture groups.
+	FindAllGroups(target string) [][]int
+}
+
 // searchWord is a case-insensitive search.
 type searchWord struct {
 	word string
@@ -110,6 +116,11 @@ func (substr regexpWord) FindAll(target string) [][]int {
 	return substr.regexp.FindAllStringIndex(target, -1)
 }
 
+// regexpWord FindAllGroups searches for strings and returns the index of the match and its capture groups.
+func (substr regexpWord) FindAllGroups(target string) [][]int {
+	return substr.regexp.FindAllStringSubmatchIndex(target, -1)
+}
+
 // regexpWord String returns the search word.
 func (substr regexpWord) String() string {
 	return substr.word
@@ -165,15 +176,20 @@ func regexpCompile(r string, caseSensitive bool) *regexp.Regexp {
 func multiRegexpCompile(words []string) []*regexp.Regexp {
 	regexps := make([]*regexp.Regexp, len(words))
 	for n, w := range words {
-		s, err := strconv.Unquote(w)
-		if err != nil {
-			s = w
-		}
-		regexps[n] = regexpCompile(s, true)
+		regexps[n] = regexpCompile(unquoteWord(w), true)
 	}
 	return regexps
 }
 
+// unquoteWord returns the word without the quotes if it is quoted.
+func unquoteWord(w string) string {
+	s, err := strconv.Unquote(w)
+	if err != nil {
+		return w
+	}
+	return s
+}
+
 // condRegexpCompile conditionally compiles a regular expression.
 func condRegexpCompile(in string) *regexp.Regexp {
 	if len(in) < 2 {
@@ -198,6 +214,13 @@ func (root *Root) searchPosition(str string) [][]int {
 
 // searchXPos returns the x position of the first match.
 func (root *Root) searchXPos(lineNum int, searcher Searcher) (int, int) {
+	if raw, ok := searcher.(rawWord); ok {
+		indexes := root.Doc.rawSearchPos(lineNum, raw)
+		if len(indexes) == 0 {
+			return 0, 0
+		}
+		return indexes[0][0], indexes[0][1]
+	}
 	line := root.Doc.getLineC(lineNum)
 	indexes := searcher.FindAll(line.str)
 	if len(indexes) == 0 {
@@ -224,6 +247,29 @@ func (root *Root) setSearcher(word string, caseSensitive bool) Searcher {
 	}
 	root.input.value = word
 
+	searcher := root.newSearcher(word, caseSensitive)
+	if root.Doc != nil && root.Doc.SearchColumn != "" {
+		s, err := root.Doc.newColumnSearcher(searcher, root.Doc.SearchColumn)
+		if err != nil {
+			root.setMessageLogf("search column %s: %v", root.Doc.SearchColumn, err)
+		} else {
+			searcher = s
+		}
+	}
+	root.searcher = searcher
+	return searcher
+}
+
+// newSearcher returns the Searcher of the word according to the search options.
+func (root *Root) newSearcher(word string, caseSensitive bool) Searcher {
+	if root.Config.ExprSearch && !root.Config.RawSearch && isSearchExpr(word) {
+		searcher, err := newSearchExpr(word, caseSensitive, root.Config.SmartCaseSensitive, root.Config.RegexpSearch)
+		if err == nil {
+			return searcher
+		}
+		// Search for the word as it is if the expression is invalid.
+	}
+
 	if root.Config.SmartCaseSensitive {
 		for _, ch := range word {
 			if unicode.IsUpper(ch) {
@@ -232,10 +278,32 @@ func (root *Root) setSearcher(word string, caseSensitive bool) Searcher {
 			}
 		}
 	}
+	if root.Config.RawSearch {
+		return newRawWord(word, caseSensitive, root.Config.RegexpSearch)
+	}
+	if n := newSearchNormalizer(root.Config.SearchNormalization, root.Config.IgnoreDiacritics, !caseSensitive); n != nil {
+		return newNormalizedWord(word, n, func(word string) Searcher {
+			return root.wordSearcher(word, caseSensitive)
+		})
+	}
+	return root.wordSearcher(word, caseSensitive)
+}
+
+// wordSearcher returns the Searcher of the word according to the search type options.
+// Fuzzy search takes precedence over regular expression search (including the "(?pcre)" prefix).
+func (root *Root) wordSearcher(word string, caseSensitive bool) Searcher {
+	if root.Config.FuzzySearch {
+		return newFuzzyWord(word, caseSensitive)
+	}
+	engine := ""
+	if root.Config.RegexpSearch && root.Doc != nil {
+		engine = root.Doc.RegexpEngine
+	}
+	if searcher := newRegexpSearcher(word, engine, caseSensitive); searcher != nil {
+		return searcher
+	}
 	reg := regexpCompile(word, caseSensitive)
-	searcher := NewSearcher(word, reg, caseSensitive, root.Config.RegexpSearch)
-	root.searcher = searcher
-	return searcher
+	return NewSearcher(word, reg, caseSensitive, root.Config.RegexpSearch)
 }
 
 // searchMove searches forward/backward and moves to the nearest matching line.
@@ -244,30 +312,57 @@ func (root *Root) searchMove(ctx context.Context, forward bool, lineNum int, sea
 		return false
 	}
 	word := searcher.String()
-	root.setMessagef("search:%v (%v)Cancel", word, strings.Join(root.cancelKeys, ","))
+	msg := fmt.Sprintf("search:%v (%v)Cancel", word, strings.Join(root.cancelKeys, ","))
+	root.setMessage(msg)
 	eg, ctx := errgroup.WithContext(ctx)
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
+	progress := &searchProgress{}
+	ctx = withProgress(ctx, progress)
+	timeouts := backtrackTimeouts(searcher)
 
 	eg.Go(func() error {
 		return root.cancelWait(cancel)
 	})
 
+	// wrapped is true if the search continued from the other end of the current document.
+	wrapped := false
 	eg.Go(func() error {
-		n, err := root.Doc.searchLine(ctx, searcher, forward, lineNum)
+		stop := root.progressTicker(progress, msg)
+		start, end := root.searchScope(ctx)
+		n, err := root.Doc.searchLineRange(ctx, searcher, forward, lineNum, start, end)
+		var doc *Document
+		if err != nil && !errors.Is(err, ErrCancel) {
+			switch {
+			case root.Config.GlobalSearch && end < 0:
+				// Global search returns to the current document after the other documents.
+				doc, n, err = root.globalSearchLine(ctx, searcher, forward)
+				wrapped = err == nil && doc == root.Doc
+			case root.Config.WrapSearch:
+				n, err = root.Doc.wrapSearchLineRange(ctx, searcher, forward, start, end)
+				wrapped = err == nil
+			}
+		}
+		stop()
 		root.sendSearchQuit()
 		if err != nil {
 			return fmt.Errorf("search:%w:%v", err, word)
 		}
-		root.sendSearchMove(n, searcher)
+		root.sendSearchMoveDocument(doc, n, searcher)
 		return nil
 	})
 
-	if err := eg.Wait(); err != nil {
-		root.setMessageLog(err.Error())
+	err := eg.Wait()
+	note := backtrackTimeoutNote(searcher, timeouts)
+	if err != nil {
+		root.setMessageLog(err.Error() + note)
 		return false
 	}
-	root.setMessagef("search:%v", word)
+	if wrapped {
+		root.setMessagef("search wrapped:%v%s", word, note)
+		return true
+	}
+	root.setMessagef("search:%v%s", word, note)
 	return true
 }
 
@@ -279,8 +374,20 @@ func (m *Document) searchLine(ctx context.Context, searcher Searcher, forward bo
 	return m.BackSearchLine(ctx, searcher, lineNum)
 }
 
+// wrapSearchLine searches from the beginning of the document (from the end if backward).
+func (m *Document) wrapSearchLine(ctx context.Context, searcher Searcher, forward bool) (int, error) {
+	if forward {
+		return m.SearchLine(ctx, searcher, m.firstLine())
+	}
+	return m.BackSearchLine(ctx, searcher, m.BufEndNum()-1)
+}
+
 // Search searches for the search term and moves to the nearest matching line.
 func (m *Document) Search(ctx context.Context, searcher Searcher, chunkNum int, lineNum int) (int, error) {
+	progressFromContext(ctx).update(chunkNum)
+	if m.skipChunk(searcher, chunkNum) {
+		return 0, ErrNotFound
+	}
 	if !m.seekable {
 		if chunkNum != 0 && m.store.lastChunkNum() <= chunkNum {
 			m.requestLoad(chunkNum)
@@ -340,6 +447,10 @@ func (m *Document) SearchChunkNonMatch(ctx context.Context, searcher Searcher, c
 
 // BackSearch searches backward from the specified line.
 func (m *Document) BackSearch(ctx context.Context, searcher Searcher, chunkNum int, line int) (int, error) {
+	progressFromContext(ctx).update(chunkNum)
+	if m.skipChunk(searcher, chunkNum) {
+		return 0, ErrNotFound
+	}
 	if !m.store.isLoadedChunk(chunkNum, m.seekable) && !m.storageSearch(searcher, chunkNum) {
 		return 0, ErrNotFound
 	}
@@ -401,6 +512,7 @@ func (m *Document) storageSearch(searcher Searcher, chunkNum int) bool {
 func (m *Document) SearchLine(ctx context.Context, searcher Searcher, lineNum int) (int, error) {
 	lineNum = max(lineNum, m.BufStartNum())
 	startChunk, sn := chunkLineNum(lineNum)
+	defer m.startProgress(ctx, startChunk, 0, true)()
 
 	for cn := startChunk; ; cn++ {
 		n, err := m.Search(ctx, searcher, cn, sn)
@@ -413,6 +525,20 @@ func (m *Document) SearchLine(ctx context.Context, searcher Searcher, lineNum in
 		default:
 		}
 
+		// The rest of the chunks are searched in parallel after the first chunk.
+		if cn == startChunk {
+			last := m.store.lastChunkNum()
+			if f := m.parallelSearchFile(last - cn); f != nil {
+				n, err := m.parallelSearch(ctx, f, searcher, true, forwardChunks(cn+1, last), 0)
+				f.Close()
+				if err == nil || errors.Is(err, ErrCancel) {
+					return n, err
+				}
+				// Continue with the chunks added during the search.
+				cn = last
+			}
+		}
+
 		// lastChunkNum may be updated by Search.
 		if cn >= m.store.lastChunkNum() {
 			lineNum = cn*ChunkSize + n
@@ -429,6 +555,7 @@ func (m *Document) BackSearchLine(ctx context.Context, searcher Searcher, lineNu
 	lineNum = min(lineNum, m.BufEndNum()-1)
 	startChunk, sn := chunkLineNum(lineNum)
 	minChunk, _ := chunkLineNum(m.BufStartNum())
+	defer m.startProgress(ctx, startChunk, minChunk, false)()
 	for cn := startChunk; cn >= minChunk; cn-- {
 		n, err := m.BackSearch(ctx, searcher, cn, sn)
 		if err == nil {
@@ -440,6 +567,15 @@ func (m *Document) BackSearchLine(ctx context.Context, searcher Searcher, lineNu
 		default:
 		}
 		sn = ChunkSize - 1
+
+		// The rest of the chunks are searched in parallel after the first chunk.
+		if cn == startChunk {
+			if f := m.parallelSearchFile(cn - minChunk); f != nil {
+				n, err := m.parallelSearch(ctx, f, searcher, false, backwardChunks(cn-1, minChunk), sn)
+				f.Close()
+				return n, err
+			}
+		}
 	}
 	return 0, ErrNotFound
 }
@@ -472,6 +608,8 @@ func (root *Root) cancelWait(cancel context.CancelFunc) error {
 			return nil
 		case *eventUpdateEndNum:
 			root.updateEndNum()
+		case *eventProgress:
+			root.showProgress(ev.p, ev.msg)
 		case *eventReachEOF:
 			eventQueue = append(eventQueue, ev)
 		default:
@@ -515,13 +653,21 @@ type eventSearchMove struct {
 	tcell.EventTime
 	ln       int
 	searcher Searcher
+	// m is the document to move to (nil for the current document).
+	m *Document
 }
 
 func (root *Root) sendSearchMove(lineNum int, searcher Searcher) {
+	root.sendSearchMoveDocument(nil, lineNum, searcher)
+}
+
+// sendSearchMoveDocument fires the eventSearchMove event to move to the line of the document.
+func (root *Root) sendSearchMoveDocument(m *Document, lineNum int, searcher Searcher) {
 	ev := &eventSearchMove{}
 	ev.SetEventNow()
 	ev.ln = lineNum
 	ev.searcher = searcher
+	ev.m = m
 	root.postEvent(ev)
 }
 
@@ -0,0 +1,199 @@
+package oviewer
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// columnSearcher is a Searcher that matches only the text of one column.
+// The columns are separated in the same way as in column mode.
+type columnSearcher struct {
+	Searcher
+	m *Document
+	// column is the index of the column (0-based).
+	column int
+	// key is the key of the column when the converter is a field converter (logfmt, parser).
+	key string
+	// parse returns the fields of the line when the converter is a field converter.
+	parse func(str string) []field
+}
+
+// newColumnSearcher returns a Searcher that limits searcher to the column.
+// The column is specified by number (1-based) or header name.
+// For field converters (logfmt, parser), the name is the key of the field.
+func (m *Document) newColumnSearcher(searcher Searcher, column string) (Searcher, error) {
+	s := columnSearcher{
+		Searcher: searcher,
+		m:        m,
+	}
+	if f := m.fieldConv(); f != nil {
+		key, err := f.columnKey(column)
+		if err != nil {
+			return nil, err
+		}
+		s.key = key
+		s.parse = f.parse
+		return s, nil
+	}
+	n, err := m.searchColumnIndex(column)
+	if err != nil {
+		return nil, err
+	}
+	s.column = n
+	return s, nil
+}
+
+// columnKey returns the key of the column specified by number (1-based) or key.
+func (f *fieldConverter) columnKey(column string) (string, error) {
+	n, err := strconv.Atoi(column)
+	if err != nil {
+		return column, nil
+	}
+	if n < 1 || n > len(f.keys) {
+		return "", ErrNoColumn
+	}
+	return f.keys[n-1], nil
+}
+
+// searchColumnIndex returns the index of the column specified by number (1-based) or header name.
+func (m *Document) searchColumnIndex(column string) (int, error) {
+	if n, err := strconv.Atoi(column); err == nil {
+		if n < 1 {
+			return 0, ErrNoColumn
+		}
+		return n - 1, nil
+	}
+	// The last header line (the first line if there is no header) has the names.
+	lineC := m.getLineC(m.SkipLines + max(m.Header, 1) - 1)
+	if !lineC.valid {
+		return 0, ErrNoColumn
+	}
+	lineC = m.columnRanges(lineC)
+	for n := range lineC.columnRanges {
+		start, end, _ := columnBounds(lineC, n)
+		if strings.EqualFold(strings.TrimSpace(lineC.str[start:end]), column) {
+			return n, nil
+		}
+	}
+	return 0, ErrNoColumn
+}
+
+// columnBounds returns the byte range of the column in lineC.str.
+func columnBounds(lineC LineC, column int) (int, int, bool) {
+	if column < 0 || column >= len(lineC.columnRanges) {
+		return 0, 0, false
+	}
+	cr := lineC.columnRanges[column]
+	return lineC.pos.n(cr.start), lineC.pos.n(cr.end), true
+}
+
+// columnSearcher Match searches only the column for bytes.
+func (s columnSearcher) Match(target []byte) bool {
+	cell, ok := s.cell(string(target))
+	return ok && s.Searcher.MatchString(cell)
+}
+
+// columnSearcher MatchString searches only the column for string.
+func (s columnSearcher) MatchString(target string) bool {
+	cell, ok := s.cell(target)
+	return ok && s.Searcher.MatchString(cell)
+}
+
+// cell returns the text of the column of the line before conversion.
+func (s columnSearcher) cell(line string) (string, bool) {
+	str := strings.TrimRight(stripEscapeSequenceString(line), "\r\n")
+	if s.parse != nil {
+		for _, f := range s.parse(str) {
+			if f.key == s.key {
+				return str[f.start:f.end], true
+			}
+		}
+		return "", false
+	}
+	lc := StrToContents(str, s.m.TabWidth)
+	str, pos := ContentsToStr(lc)
+	lineC := LineC{lc: lc, str: str, pos: pos}
+	lineC.columnRanges = s.m.searchColumnRanges(lineC)
+	start, end, ok := columnBounds(lineC, s.column)
+	if !ok {
+		return "", false
+	}
+	return str[start:end], true
+}
+
+// searchColumnRanges returns the column ranges of the line before conversion.
+// The alignment of the align converter is not applied to the line, so the original widths are used.
+func (m *Document) searchColumnRanges(lineC LineC) []columnRange {
+	if !m.ColumnWidth {
+		return m.columnDelimiterRange(lineC)
+	}
+	widths := m.columnWidths
+	if len(widths) == 0 {
+		return nil
+	}
+	var ranges []columnRange
+	start, lEnd := outerBorders(lineC.lc)
+	for c := range len(widths) + 1 {
+		end := min(findColumnEnd(lineC.lc, widths, c, start), lEnd)
+		if start > end {
+			break
+		}
+		ranges = append(ranges, columnRange{start: start, end: end})
+		start = end + 1
+	}
+	return ranges
+}
+
+// columnSearcher FindAll returns the index of the matches in the column of the displayed line.
+func (s columnSearcher) FindAll(target string) [][]int {
+	lc := StrToContents(target, s.m.TabWidth)
+	str, pos := ContentsToStr(lc)
+	lineC := s.m.columnRanges(LineC{lc: lc, str: str, pos: pos})
+	column := s.column
+	if s.parse != nil {
+		f := s.m.fieldConv()
+		if f == nil {
+			return nil
+		}
+		n, ok := f.keyNum(s.key)
+		if !ok {
+			return nil
+		}
+		column = n
+	}
+	start, end, ok := columnBounds(lineC, column)
+	if !ok || str != target {
+		return nil
+	}
+	indexes := s.Searcher.FindAll(target[start:end])
+	for _, idx := range indexes {
+		idx[0] += start
+		idx[1] += start
+	}
+	return indexes
+}
+
+// searchColumnOpt returns the prompt option of the search column.
+func (m *Document) searchColumnOpt() string {
+	if m.SearchColumn == "" {
+		return ""
+	}
+	return "(C:" + m.SearchColumn + ")"
+}
+
+// toggleColumnSearch toggles the search limited to the column at the cursor.
+func (root *Root) toggleColumnSearch(context.Context) {
+	m := root.Doc
+	if m.SearchColumn != "" {
+		m.SearchColumn = ""
+		root.setPromptOpt()
+		return
+	}
+	if !m.ColumnMode {
+		root.setMessage("column search requires column mode")
+		return
+	}
+	m.SearchColumn = strconv.Itoa(m.columnCursor + 1)
+	root.setPromptOpt()
+}
@@ -0,0 +1,246 @@
+package oviewer
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func columnSearchDocHelper(t *testing.T, str string, header int) *Document {
+	t.Helper()
+	m := docHelper(t, str)
+	m.ColumnDelimiter = ","
+	m.Header = header
+	m.regexpCompile()
+	return m
+}
+
+func TestDocument_newColumnSearcher(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		column string
+		word   string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		target  string
+		want    bool
+		wantErr error
+	}{
+		{
+			name:   "number",
+			args:   args{column: "3", word: "500"},
+			target: "1,foo,500\n",
+			want:   true,
+		},
+		{
+			name:   "numberOtherColumn",
+			args:   args{column: "3", word: "500"},
+			target: "2,500,200\n",
+			want:   false,
+		},
+		{
+			name:   "headerName",
+			args:   args{column: "status", word: "500"},
+			target: "1,foo,500\n",
+			want:   true,
+		},
+		{
+			name:   "headerNameCase",
+			args:   args{column: "Name", word: "500"},
+			target: "2,500,200\n",
+			want:   true,
+		},
+		{
+			name:   "noColumnInLine",
+			args:   args{column: "4", word: "500"},
+			target: "1,foo,500\n",
+			want:   false,
+		},
+		{
+			name:   "escapeSequence",
+			args:   args{column: "2", word: "foo"},
+			target: "1,\x1b[31mfoo\x1b[m,500\n",
+			want:   true,
+		},
+		{
+			name:    "zero",
+			args:    args{column: "0", word: "500"},
+			wantErr: ErrNoColumn,
+		},
+		{
+			name:    "unknownName",
+			args:    args{column: "unknown", word: "500"},
+			wantErr: ErrNoColumn,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := columnSearchDocHelper(t, "id,name,status\n1,foo,500\n2,500,200\n", 1)
+			searcher, err := m.newColumnSearcher(NewSearcher(tt.args.word, nil, false, false), tt.args.column)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("newColumnSearcher() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("newColumnSearcher() error = %v", err)
+			}
+			if got := searcher.Match([]byte(tt.target)); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+			if got := searcher.MatchString(tt.target); got != tt.want {
+				t.Errorf("MatchString() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_columnSearcher_cellBorder(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		column string
+		want   string
+	}{
+		{name: "first", column: "1", want: " a  "},
+		{name: "last", column: "2", want: " bb "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := docHelper(t, "│ a  │ bb │\n")
+			m.ColumnWidth = true
+			m.columnWidths = []int{5}
+			searcher, err := m.newColumnSearcher(NewSearcher("a", nil, false, false), tt.column)
+			if err != nil {
+				t.Fatalf("newColumnSearcher() error = %v", err)
+			}
+			got, ok := searcher.(columnSearcher).cell("│ a  │ bb │")
+			if !ok || got != tt.want {
+				t.Errorf("cell() = %q, %v, want %q", got, ok, tt.want)
+			}
+		})
+	}
+}
+
+func Test_columnSearcher_FindAll(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		column string
+		word   string
+	}
+	tests := []struct {
+		name   string
+		args   args
+		target string
+		want   [][]int
+	}{
+		{
+			name:   "second",
+			args:   args{column: "2", word: "500"},
+			target: "2,500,500",
+			want:   [][]int{{2, 5}},
+		},
+		{
+			name:   "third",
+			args:   args{column: "3", word: "500"},
+			target: "2,500,500",
+			want:   [][]int{{6, 9}},
+		},
+		{
+			name:   "notMatch",
+			args:   args{column: "1", word: "500"},
+			target: "2,500,500",
+			want:   nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := columnSearchDocHelper(t, "id,name,status\n", 1)
+			searcher, err := m.newColumnSearcher(NewSearcher(tt.args.word, nil, false, false), tt.args.column)
+			if err != nil {
+				t.Fatalf("newColumnSearcher() error = %v", err)
+			}
+			if got := searcher.FindAll(tt.target); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindAll() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDocument_newColumnSearcherLogfmt(t *testing.T) {
+	t.Parallel()
+	m := docHelper(t, "level=info msg=500\nlevel=500 msg=ok\n")
+	m.Converter = convLogfmt
+	m.conv = m.converterType(m.Converter)
+	searcher, err := m.newColumnSearcher(NewSearcher("500", nil, false, false), "msg")
+	if err != nil {
+		t.Fatalf("newColumnSearcher() error = %v", err)
+	}
+	if !searcher.Match([]byte("level=info msg=500\n")) {
+		t.Errorf("Match() = false, want true")
+	}
+	if searcher.Match([]byte("level=500 msg=ok\n")) {
+		t.Errorf("Match() = true, want false")
+	}
+}
+
+func TestRoot_setSearcherColumn(t *testing.T) {
+	tcellNewScreen = fakeScreen
+	defer func() {
+		tcellNewScreen = tcell.NewScreen
+	}()
+	root := rootFileReadHelper(t, filepath.Join(testdata, "MOCK_DATA.csv"))
+	root.Doc.ColumnDelimiter = ","
+	root.Doc.Header = 1
+	root.Doc.regexpCompile()
+	root.Doc.SearchColumn = "first_name"
+	searcher := root.setSearcher("binky", false)
+	if _, ok := searcher.(columnSearcher); !ok {
+		t.Fatalf("setSearcher() = %T, want columnSearcher", searcher)
+	}
+	lN, err := root.Doc.SearchLine(context.Background(), searcher, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lN != 3 {
+		t.Errorf("SearchLine() = %v, want %v", lN, 3)
+	}
+
+	root.Doc.SearchColumn = "unknown"
+	searcher = root.setSearcher("binky", false)
+	if _, ok := searcher.(columnSearcher); ok {
+		t.Errorf("setSearcher() with unknown column = %T, want the searcher of the whole line", searcher)
+	}
+}
+
+func TestRoot_toggleColumnSearch(t *testing.T) {
+	tcellNewScreen = fakeScreen
+	defer func() {
+		tcellNewScreen = tcell.NewScreen
+	}()
+	root := rootFileReadHelper(t, filepath.Join(testdata, "MOCK_DATA.csv"))
+	root.toggleColumnSearch(context.Background())
+	if root.Doc.SearchColumn != "" {
+		t.Errorf("toggleColumnSearch() without column mode = %q, want empty", root.Doc.SearchColumn)
+	}
+	root.Doc.ColumnMode = true
+	root.Doc.columnCursor = 2
+	root.toggleColumnSearch(context.Background())
+	if root.Doc.SearchColumn != "3" {
+		t.Errorf("toggleColumnSearch() = %q, want %q", root.Doc.SearchColumn, "3")
+	}
+	root.toggleColumnSearch(context.Background())
+	if root.Doc.SearchColumn != "" {
+		t.Errorf("toggleColumnSearch() = %q, want empty", root.Doc.SearchColumn)
+	}
+}
@@ -0,0 +1,517 @@
+package oviewer
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// searchExpr is a search that combines multiple search terms with AND, OR and NOT.
+//
+//	error AND NOT timeout
+//	(db | cache) AND latency
+//	"exact phrase" OR /regex/i
+//	status>=500 AND latency>1s
+//
+// Adjacent terms without an operator are combined with AND.
+type searchExpr struct {
+	word string
+	root *exprNode
+	// positives are the terms that are not negated, used for highlighting.
+	positives []Searcher
+}
+
+// exprOp is the operator of the expression node.
+type exprOp int
+
+const (
+	exprTerm exprOp = iota
+	exprAnd
+	exprOr
+	exprNot
+)
+
+// exprNode is a node of the search expression.
+type exprNode struct {
+	op       exprOp
+	searcher Searcher
+	children []*exprNode
+}
+
+// match reports whether the node matches the target (the escape sequences have been removed).
+func (n *exprNode) match(target string) bool {
+	switch n.op {
+	case exprAnd:
+		for _, c := range n.children {
+			if !c.match(target) {
+				return false
+			}
+		}
+		return true
+	case exprOr:
+		for _, c := range n.children {
+			if c.match(target) {
+				return true
+			}
+		}
+		return false
+	case exprNot:
+		return !n.children[0].match(target)
+	default:
+		return n.searcher.MatchString(target)
+	}
+}
+
+// positives returns the terms that are not negated.
+func (n *exprNode) positives(negated bool) []Searcher {
+	switch n.op {
+	case exprTerm:
+		if negated {
+			return nil
+		}
+		return []Searcher{n.searcher}
+	case exprNot:
+		return n.children[0].positives(!negated)
+	default:
+		var searchers []Searcher
+		for _, c := range n.children {
+			searchers = append(searchers, c.positives(negated)...)
+		}
+		return searchers
+	}
+}
+
+// searchExpr Match is a search for bytes.
+func (expr searchExpr) Match(target []byte) bool {
+	return expr.root.match(string(stripEscapeSequenceBytes(target)))
+}
+
+// searchExpr MatchString is a search for string.
+func (expr searchExpr) MatchString(target string) bool {
+	return expr.root.match(stripEscapeSequenceString(target))
+}
+
+// searchExpr FindAll returns the merged index of the matches of all positive terms.
+// Returns nil if the target does not match the expression.
+func (expr searchExpr) FindAll(target string) [][]int {
+	if !expr.MatchString(target) {
+		return nil
+	}
+	var indexes [][]int
+	for _, s := range expr.positives {
+		indexes = append(indexes, s.FindAll(target)...)
+	}
+	return mergeIndexes(indexes)
+}
+
+// searchExpr String returns the search word.
+func (expr searchExpr) String() string {
+	return expr.word
+}
+
+// mergeIndexes sorts the indexes and merges the overlapping ones.
+func mergeIndexes(indexes [][]int) [][]int {
+	if len(indexes) == 0 {
+		return nil
+	}
+	slices.SortFunc(indexes, func(a, b []int) int {
+		if a[0] != b[0] {
+			return a[0] - b[0]
+		}
+		return a[1] - b[1]
+	})
+	merged := [][]int{{indexes[0][0], indexes[0][1]}}
+	for _, idx := range indexes[1:] {
+		last := merged[len(merged)-1]
+		if idx[0] < last[1] {
+			last[1] = max(last[1], idx[1])
+			continue
+		}
+		merged = append(merged, []int{idx[0], idx[1]})
+	}
+	return merged
+}
+
+// exprTokenType is the type of the token of the search expression.
+type exprTokenType int
+
+const (
+	tokenWord exprTokenType = iota
+	tokenPhrase
+	tokenRegexp
+	tokenCompare
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenOpen
+	tokenClose
+)
+
+// exprToken is a token of the search expression.
+type exprToken struct {
+	typ  exprTokenType
+	str  string
+	flag string
+	// key and op are the key and the operator of the comparison.
+	key string
+	op  string
+}
+
+// isSearchExpr reports whether the word is a search expression.
+// It is a search expression if it contains the AND, OR or NOT operator,
+// or a comparison such as latency>500.
+func isSearchExpr(word string) bool {
+	tokens, err := tokenizeExpr(word)
+	if err != nil {
+		return false
+	}
+	for _, t := range tokens {
+		if t.typ == tokenAnd || t.typ == tokenCompare || t.typ == tokenNot || (t.typ == tokenOr && t.str != "|") {
+			return true
+		}
+	}
+	return false
+}
+
+// tokenizeExpr splits the search expression into tokens.
+func tokenizeExpr(expr string) ([]exprToken, error) {
+	var tokens []exprToken
+	for i := 0; i < len(expr); {
+		switch c := expr[i]; {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '(':
+			tokens = append(tokens, exprToken{typ: tokenOpen, str: "("})
+			i++
+		case c == ')':
+			tokens = append(tokens, exprToken{typ: tokenClose, str: ")"})
+			i++
+		case strings.HasPrefix(expr[i:], "||"):
+			tokens = append(tokens, exprToken{typ: tokenOr, str: "||"})
+			i += 2
+		case c == '|':
+			tokens = append(tokens, exprToken{typ: tokenOr, str: "|"})
+			i++
+		case c == '"':
+			end := quoteEnd(expr, i)
+			if end < 0 {
+				return nil, ErrSearchExprUnclosed
+			}
+			s, err := strconv.Unquote(expr[i : end+1])
+			if err != nil {
+				s = expr[i+1 : end]
+			}
+			tokens = append(tokens, exprToken{typ: tokenPhrase, str: s})
+			i = end + 1
+		default:
+			if t, n, ok := regexpToken(expr[i:]); ok {
+				tokens = append(tokens, t)
+				i += n
+				continue
+			}
+			n := strings.IndexAny(expr[i:], " \t()|")
+			if n < 0 {
+				n = len(expr) - i
+			}
+			tokens = append(tokens, wordToken(expr[i:i+n]))
+			i += n
+		}
+	}
+	return tokens, nil
+}
+
+// quoteEnd returns the index of the closing double quote.
+func quoteEnd(expr string, start int) int {
+	for i := start + 1; i < len(expr); i++ {
+		switch expr[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+// regexpToken returns the /regexp/ token at the beginning of s and its length.
+// Only the "i" flag (case-insensitive) is allowed after the closing slash.
+func regexpToken(s string) (exprToken, int, bool) {
+	if len(s) < 3 || s[0] != '/' {
+		return exprToken{}, 0, false
+	}
+	end := -1
+	for i := 1; i < len(s); i++ {
+		if s[i] == '\\' {
+			i++
+			continue
+		}
+		if s[i] == '/' {
+			end = i
+			break
+		}
+	}
+	if end <= 1 {
+		return exprToken{}, 0, false
+	}
+	n := end + 1
+	flag := ""
+	if n < len(s) && s[n] == 'i' {
+		flag = "i"
+		n++
+	}
+	if n < len(s) && !strings.ContainsRune(" \t()|", rune(s[n])) {
+		return exprToken{}, 0, false
+	}
+	return exprToken{typ: tokenRegexp, str: s[1:end], flag: flag}, n, true
+}
+
+// wordToken returns the token of the word, which may be an operator.
+func wordToken(s string) exprToken {
+	switch s {
+	case "AND", "&&":
+		return exprToken{typ: tokenAnd, str: s}
+	case "OR", "||":
+		return exprToken{typ: tokenOr, str: s}
+	case "NOT":
+		return exprToken{typ: tokenNot, str: s}
+	}
+	if key, op, value, ok := splitCompare(s); ok {
+		return exprToken{typ: tokenCompare, str: value, key: key, op: op}
+	}
+	return exprToken{typ: tokenWord, str: s}
+}
+
+// compareKey matches the key of the comparison.
+var compareKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*`)
+
+// splitCompare splits the comparison word such as latency>500 into the key, the operator and the value.
+// A single "=" is not a comparison, so key=value is searched as a word.
+func splitCompare(s string) (string, string, string, bool) {
+	key := compareKey.FindString(s)
+	if key == "" {
+		return "", "", "", false
+	}
+	op := filterOpPrefix(s[len(key):])
+	if op == "" || op == "=" {
+		return "", "", "", false
+	}
+	value := s[len(key)+len(op):]
+	if value == "" {
+		return "", "", "", false
+	}
+	return key, op, value, true
+}
+
+// exprParser is a parser of the search expression.
+type exprParser struct {
+	tokens []exprToken
+	pos    int
+	// caseSensitive is applied to all terms.
+	caseSensitive bool
+	// smartCase makes the term that contains uppercase letters case-sensitive.
+	smartCase bool
+	// regexpSearch interprets the words as regular expressions.
+	regexpSearch bool
+}
+
+// newSearchExpr returns the Searcher of the search expression.
+func newSearchExpr(word string, caseSensitive bool, smartCase bool, regexpSearch bool) (Searcher, error) {
+	tokens, err := tokenizeExpr(word)
+	if err != nil {
+		return nil, err
+	}
+	p := &exprParser{
+		tokens:        tokens,
+		caseSensitive: caseSensitive,
+		smartCase:     smartCase,
+		regexpSearch:  regexpSearch,
+	}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, ErrSearchExprSyntax
+	}
+	return searchExpr{
+		word:      word,
+		root:      node,
+		positives: node.positives(false),
+	}, nil
+}
+
+// peek returns the current token.
+func (p *exprParser) peek() (exprToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return exprToken{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+// parseOr parses: and { OR and }.
+func (p *exprParser) parseOr() (*exprNode, error) {
+	node, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	children := []*exprNode{node}
+	for {
+		t, ok := p.peek()
+		if !ok || t.typ != tokenOr {
+			break
+		}
+		p.pos++
+		node, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, node)
+	}
+	if len(children) == 1 {
+		return children[0], nil
+	}
+	return &exprNode{op: exprOr, children: children}, nil
+}
+
+// parseAnd parses: unary { [AND] unary }.
+func (p *exprParser) parseAnd() (*exprNode, error) {
+	node, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	children := []*exprNode{node}
+	for {
+		t, ok := p.peek()
+		if !ok || t.typ == tokenOr || t.typ == tokenClose {
+			break
+		}
+		if t.typ == tokenAnd {
+			p.pos++
+		}
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, node)
+	}
+	if len(children) == 1 {
+		return children[0], nil
+	}
+	return &exprNode{op: exprAnd, children: children}, nil
+}
+
+// parseUnary parses: NOT unary | ( or ) | term.
+func (p *exprParser) parseUnary() (*exprNode, error) {
+	t, ok := p.peek()
+	if !ok {
+		return nil, ErrSearchExprSyntax
+	}
+	p.pos++
+	switch t.typ {
+	case tokenNot:
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &exprNode{op: exprNot, children: []*exprNode{node}}, nil
+	case tokenOpen:
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if t, ok := p.peek(); !ok || t.typ != tokenClose {
+			return nil, ErrSearchExprUnclosed
+		}
+		p.pos++
+		return node, nil
+	case tokenWord, tokenPhrase, tokenRegexp, tokenCompare:
+		searcher, err := p.termSearcher(t)
+		if err != nil {
+			return nil, err
+		}
+		return &exprNode{op: exprTerm, searcher: searcher}, nil
+	default:
+		return nil, ErrSearchExprSyntax
+	}
+}
+
+// termSearcher returns the Searcher of the term.
+func (p *exprParser) termSearcher(t exprToken) (Searcher, error) {
+	caseSensitive := p.caseSensitive
+	if p.smartCase && strings.IndexFunc(t.str, unicode.IsUpper) >= 0 {
+		caseSensitive = true
+	}
+	switch t.typ {
+	case tokenRegexp:
+		opt := ""
+		if t.flag == "i" || !caseSensitive {
+			opt = "(?i)"
+		}
+		re, err := regexp.Compile(opt + t.str)
+		if err != nil {
+			return nil, err
+		}
+		return regexpWord{word: t.str, regexp: re}, nil
+	case tokenPhrase:
+		return NewSearcher(t.str, nil, caseSensitive, false), nil
+	case tokenCompare:
+		return newKeyPredicate(t.key, t.op, t.str, caseSensitive)
+	default:
+		return NewSearcher(t.str, regexpCompile(t.str, caseSensitive), caseSensitive, p.regexpSearch), nil
+	}
+}
+
+// keyPredicate is a Searcher that compares the value of the key in the line,
+// such as latency=500 (logfmt) or "latency": 500 (JSON).
+type keyPredicate struct {
+	predicate
+	// re finds the value of the key.
+	re *regexp.Regexp
+}
+
+// newKeyPredicate returns the keyPredicate of key op value.
+func newKeyPredicate(key string, op string, value string, caseSensitive bool) (keyPredicate, error) {
+	pred, err := newPredicate(op, []string{value}, false, caseSensitive)
+	if err != nil {
+		return keyPredicate{}, err
+	}
+	pred.word = key + op + value
+	opt := ""
+	if !caseSensitive {
+		opt = "(?i)"
+	}
+	re := regexp.MustCompile(opt + `(?:^|[^\w.])"?` + regexp.QuoteMeta(key) + `"?\s*[:=]\s*(?:"((?:[^"\\]|\\.)*)"|([^\s,;}\]"]+))`)
+	return keyPredicate{predicate: pred, re: re}, nil
+}
+
+// keyPredicate Match is a search for bytes.
+func (k keyPredicate) Match(target []byte) bool {
+	return k.MatchString(string(target))
+}
+
+// keyPredicate MatchString is a search for string.
+func (k keyPredicate) MatchString(target string) bool {
+	return len(k.values(stripEscapeSequenceString(target))) > 0
+}
+
+// keyPredicate FindAll returns the index of the values that match.
+func (k keyPredicate) FindAll(target string) [][]int {
+	return k.values(target)
+}
+
+// values returns the index of the values of the key that match the predicate.
+func (k keyPredicate) values(target string) [][]int {
+	var indexes [][]int
+	for _, m := range k.re.FindAllStringSubmatchIndex(target, -1) {
+		start, end := m[2], m[3]
+		if start < 0 {
+			start, end = m[4], m[5]
+		}
+		if k.predicate.MatchString(target[start:end]) {
+			indexes = append(indexes, []int{start, end})
+		}
+	}
+	return indexes
+}
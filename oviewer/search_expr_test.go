@@ -0,0 +1,297 @@
+package oviewer
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func Test_isSearchExpr(t *testing.T) {
+	tests := []struct {
+		name string
+		word string
+		want bool
+	}{
+		{name: "word", word: "error", want: false},
+		{name: "words", word: "error timeout", want: false},
+		{name: "and", word: "error AND timeout", want: true},
+		{name: "or", word: "error OR timeout", want: true},
+		{name: "not", word: "NOT timeout", want: true},
+		{name: "lowercase", word: "error and timeout", want: false},
+		{name: "pipe", word: "db|cache", want: false},
+		{name: "doublePipe", word: "db || cache", want: true},
+		{name: "quotedOperator", word: `"error AND timeout"`, want: false},
+		{name: "unclosedQuote", word: `"error AND timeout`, want: false},
+		{name: "compare", word: "latency>500", want: true},
+		{name: "compareNotEqual", word: "level!=info", want: true},
+		{name: "equal", word: "level=error", want: false},
+		{name: "arrow", word: "a->b", want: false},
+		{name: "noValue", word: "latency>", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isSearchExpr(tt.word); got != tt.want {
+				t.Errorf("isSearchExpr() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_searchExpr_MatchString(t *testing.T) {
+	type args struct {
+		word          string
+		caseSensitive bool
+		smartCase     bool
+		regexpSearch  bool
+	}
+	tests := []struct {
+		name   string
+		args   args
+		target string
+		want   bool
+	}{
+		{
+			name:   "and",
+			args:   args{word: "error AND db"},
+			target: "error: db connection",
+			want:   true,
+		},
+		{
+			name:   "andNotMatch",
+			args:   args{word: "error AND db"},
+			target: "error: cache connection",
+			want:   false,
+		},
+		{
+			name:   "andNot",
+			args:   args{word: "error AND NOT timeout"},
+			target: "ERROR: timeout",
+			want:   false,
+		},
+		{
+			name:   "implicitAnd",
+			args:   args{word: "NOT timeout error"},
+			target: "error: refused",
+			want:   true,
+		},
+		{
+			name:   "group",
+			args:   args{word: "(db|cache) AND latency"},
+			target: "cache latency=600",
+			want:   true,
+		},
+		{
+			name:   "groupNotMatch",
+			args:   args{word: "(db|cache) AND latency"},
+			target: "api latency=600",
+			want:   false,
+		},
+		{
+			name:   "phrase",
+			args:   args{word: `"exact phrase" OR /err(or)?/i`},
+			target: "this is an exact phrase",
+			want:   true,
+		},
+		{
+			name:   "phraseNotMatch",
+			args:   args{word: `"exact phrase" OR /^err(or)?$/`},
+			target: "exact and phrase",
+			want:   false,
+		},
+		{
+			name:   "regexpFlag",
+			args:   args{word: `"exact phrase" OR /err(or)?/i`},
+			target: "ERR",
+			want:   true,
+		},
+		{
+			name:   "regexpIgnoreCase",
+			args:   args{word: "/err(or)?/ AND db"},
+			target: "ERROR DB",
+			want:   true,
+		},
+		{
+			name:   "regexpCaseSensitive",
+			args:   args{word: "/err(or)?/ AND db", caseSensitive: true},
+			target: "ERROR db",
+			want:   false,
+		},
+		{
+			name:   "regexpSmartCase",
+			args:   args{word: "/Err(or)?/ OR db", smartCase: true},
+			target: "error",
+			want:   false,
+		},
+		{
+			name:   "compare",
+			args:   args{word: "latency>500"},
+			target: "api latency=600",
+			want:   true,
+		},
+		{
+			name:   "compareNotMatch",
+			args:   args{word: "latency>500"},
+			target: "api latency=400",
+			want:   false,
+		},
+		{
+			name:   "compareNoKey",
+			args:   args{word: "latency>500"},
+			target: "api 600",
+			want:   false,
+		},
+		{
+			name:   "compareJSON",
+			args:   args{word: "db AND status>=500"},
+			target: `{"msg":"db","status": 503}`,
+			want:   true,
+		},
+		{
+			name:   "compareDuration",
+			args:   args{word: "latency>1s"},
+			target: "latency=1.5s",
+			want:   true,
+		},
+		{
+			name:   "compareString",
+			args:   args{word: "level!=info"},
+			target: `{"level":"ERROR"}`,
+			want:   true,
+		},
+		{
+			name:   "compareStringNotMatch",
+			args:   args{word: "level!=info"},
+			target: "level=INFO",
+			want:   false,
+		},
+		{
+			name:   "caseSensitive",
+			args:   args{word: "Error AND db", caseSensitive: true},
+			target: "error db",
+			want:   false,
+		},
+		{
+			name:   "smartCase",
+			args:   args{word: "Error OR DB", smartCase: true},
+			target: "error db",
+			want:   false,
+		},
+		{
+			name:   "smartCaseLower",
+			args:   args{word: "error AND DB", smartCase: true},
+			target: "Error DB",
+			want:   true,
+		},
+		{
+			name:   "regexpSearch",
+			args:   args{word: "e.r AND db", regexpSearch: true},
+			target: "err db",
+			want:   true,
+		},
+		{
+			name:   "escapeSequence",
+			args:   args{word: "error AND db"},
+			target: "\x1b[31merror\x1b[m db",
+			want:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			searcher, err := newSearchExpr(tt.args.word, tt.args.caseSensitive, tt.args.smartCase, tt.args.regexpSearch)
+			if err != nil {
+				t.Fatalf("newSearchExpr() error = %v", err)
+			}
+			if got := searcher.MatchString(tt.target); got != tt.want {
+				t.Errorf("MatchString() = %v, want %v", got, tt.want)
+			}
+			if got := searcher.Match([]byte(tt.target)); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_searchExpr_FindAll(t *testing.T) {
+	tests := []struct {
+		name   string
+		word   string
+		target string
+		want   [][]int
+	}{
+		{
+			name:   "and",
+			word:   "error AND db",
+			target: "db error db",
+			want:   [][]int{{0, 2}, {3, 8}, {9, 11}},
+		},
+		{
+			name:   "notIsNotHighlighted",
+			word:   "error AND NOT timeout",
+			target: "error: refused",
+			want:   [][]int{{0, 5}},
+		},
+		{
+			name:   "notMatch",
+			word:   "error AND NOT timeout",
+			target: "error: timeout",
+			want:   nil,
+		},
+		{
+			name:   "doubleNot",
+			word:   "NOT NOT error",
+			target: "error",
+			want:   [][]int{{0, 5}},
+		},
+		{
+			name:   "compare",
+			word:   "latency>500 OR db",
+			target: "db latency=600 other=700",
+			want:   [][]int{{0, 2}, {11, 14}},
+		},
+		{
+			name:   "compareQuoted",
+			word:   "status>=500",
+			target: `{"status":"503"}`,
+			want:   [][]int{{11, 14}},
+		},
+		{
+			name:   "overlap",
+			word:   "error OR /err/",
+			target: "error",
+			want:   [][]int{{0, 5}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			searcher, err := newSearchExpr(tt.word, false, false, false)
+			if err != nil {
+				t.Fatalf("newSearchExpr() error = %v", err)
+			}
+			if got := searcher.FindAll(tt.target); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindAll() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_newSearchExprError(t *testing.T) {
+	tests := []struct {
+		name    string
+		word    string
+		wantErr error
+	}{
+		{name: "trailingOperator", word: "error AND", wantErr: ErrSearchExprSyntax},
+		{name: "leadingOperator", word: "OR error", wantErr: ErrSearchExprSyntax},
+		{name: "unclosedParen", word: "(error OR db", wantErr: ErrSearchExprUnclosed},
+		{name: "extraParen", word: "error OR db)", wantErr: ErrSearchExprSyntax},
+		{name: "unclosedQuote", word: `"error OR db`, wantErr: ErrSearchExprUnclosed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newSearchExpr(tt.word, false, false, false)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("newSearchExpr() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,313 @@
+package oviewer
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// fuzzyWord is a Searcher that matches the characters of the word in order
+// (not necessarily consecutively), like fzf.
+// A few characters of a long word may be missing to tolerate typos.
+type fuzzyWord struct {
+	word    string
+	pattern []rune
+	// caseSensitive compares the characters as they are.
+	caseSensitive bool
+	// maxTypos is the number of characters of the word that may be missing.
+	maxTypos int
+}
+
+// Scores of the fuzzy match (similar to fzf).
+const (
+	fuzzyScoreMatch        = 16
+	fuzzyScoreGapStart     = -3
+	fuzzyScoreGapExtension = -1
+	fuzzyScoreTypo         = -12
+	fuzzyBonusBoundary     = 8
+	fuzzyBonusCamel        = 7
+	fuzzyBonusConsecutive  = 4
+	fuzzyBonusFirstChar    = 2
+)
+
+// fuzzyMaxCells is the maximum size of the table to find the best positions.
+// For longer lines, the positions found first are used.
+const fuzzyMaxCells = 1 << 20
+
+// newFuzzyWord returns the Searcher of the fuzzy search.
+func newFuzzyWord(word string, caseSensitive bool) fuzzyWord {
+	if !caseSensitive {
+		word = strings.ToLower(word)
+	}
+	pattern := []rune(word)
+	return fuzzyWord{
+		word:          word,
+		pattern:       pattern,
+		caseSensitive: caseSensitive,
+		maxTypos:      len(pattern) / 5,
+	}
+}
+
+// fuzzyWord Match is a fuzzy search for bytes.
+func (f fuzzyWord) Match(target []byte) bool {
+	return f.MatchString(string(target))
+}
+
+// fuzzyWord MatchString is a fuzzy search for string.
+func (f fuzzyWord) MatchString(target string) bool {
+	_, ok := f.subsequence([]rune(stripEscapeSequenceString(target)))
+	return ok
+}
+
+// fuzzyWord FindAll returns the index of the matched characters.
+// Consecutive characters are combined into one range.
+func (f fuzzyWord) FindAll(target string) [][]int {
+	positions, _, ok := f.positions(target)
+	if !ok {
+		return nil
+	}
+	var indexes [][]int
+	for _, pos := range positions {
+		_, size := utf8.DecodeRuneInString(target[pos:])
+		if n := len(indexes); n > 0 && indexes[n-1][1] == pos {
+			indexes[n-1][1] = pos + size
+			continue
+		}
+		indexes = append(indexes, []int{pos, pos + size})
+	}
+	return indexes
+}
+
+// fuzzyWord String returns the search word.
+func (f fuzzyWord) String() string {
+	return f.word
+}
+
+// score returns the score of the target.
+// The higher the score, the better the match.
+func (f fuzzyWord) score(target string) (int, bool) {
+	target = stripEscapeSequenceString(target)
+	positions, typos, ok := f.positions(target)
+	if !ok {
+		return 0, false
+	}
+	return fuzzyScore(target, positions, typos), true
+}
+
+// subsequence searches the characters of the pattern in order.
+// It returns whether each character of the pattern is found.
+// Characters that are not found are typos, and the match fails if there are too many.
+func (f fuzzyWord) subsequence(text []rune) ([]bool, bool) {
+	if len(f.pattern) == 0 {
+		return nil, false
+	}
+	found := make([]bool, len(f.pattern))
+	typos, pos := 0, 0
+	for p, pr := range f.pattern {
+		i := f.indexRune(text, pos, pr)
+		if i < 0 {
+			typos++
+			if typos > f.maxTypos {
+				return nil, false
+			}
+			continue
+		}
+		found[p] = true
+		pos = i + 1
+	}
+	return found, typos < len(f.pattern)
+}
+
+// positions returns the byte positions of the matched characters of target
+// that give the best score, and the number of typos.
+func (f fuzzyWord) positions(target string) ([]int, int, bool) {
+	text, offsets := decodeRunes(target)
+	found, ok := f.subsequence(text)
+	if !ok {
+		return nil, 0, false
+	}
+	pattern := make([]rune, 0, len(f.pattern))
+	for p, pr := range f.pattern {
+		if found[p] {
+			pattern = append(pattern, pr)
+		}
+	}
+	var runePos []int
+	if len(text)*len(pattern) > fuzzyMaxCells {
+		runePos = f.firstPositions(text, pattern)
+	} else {
+		runePos = f.bestPositions(text, pattern)
+	}
+
+	positions := make([]int, len(runePos))
+	for i, n := range runePos {
+		positions[i] = offsets[n]
+	}
+	return positions, len(f.pattern) - len(pattern), true
+}
+
+// decodeRunes returns the runes of s and their byte offsets.
+// Invalid UTF-8 bytes are decoded as utf8.RuneError of one byte,
+// so the offsets always point into s.
+func decodeRunes(s string) ([]rune, []int) {
+	text := make([]rune, 0, len(s))
+	offsets := make([]int, 0, len(s))
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		text = append(text, r)
+		offsets = append(offsets, i)
+		i += size
+	}
+	return text, offsets
+}
+
+// firstPositions returns the rune positions of the pattern found first.
+func (f fuzzyWord) firstPositions(text []rune, pattern []rune) []int {
+	positions := make([]int, 0, len(pattern))
+	pos := 0
+	for _, pr := range pattern {
+		i := f.indexRune(text, pos, pr)
+		positions = append(positions, i)
+		pos = i + 1
+	}
+	return positions
+}
+
+// bestPositions returns the rune positions of the pattern with the best score.
+// The pattern must be a subsequence of the text.
+func (f fuzzyWord) bestPositions(text []rune, pattern []rune) []int {
+	const none = -1 << 30
+	n, m := len(text), len(pattern)
+	bonus := make([]int, n)
+	for j, r := range text {
+		prev := ' '
+		if j > 0 {
+			prev = text[j-1]
+		}
+		bonus[j] = fuzzyBonus(prev, r)
+	}
+
+	// score[i][j] is the best score when pattern[i] matches text[j],
+	// and from[i][j] is the position of pattern[i-1] in that case.
+	score := make([][]int, m)
+	from := make([][]int, m)
+	for i := range m {
+		score[i] = make([]int, n)
+		from[i] = make([]int, n)
+		// gap is the best score of pattern[i-1] at k <= j-2 including the gap penalty to j.
+		gap, gapFrom := none, -1
+		for j := range n {
+			score[i][j] = none
+			if i > 0 && j >= 2 && score[i-1][j-2] != none {
+				if s := score[i-1][j-2] + fuzzyScoreGapStart; s > gap+fuzzyScoreGapExtension {
+					gap, gapFrom = s, j-2
+				} else {
+					gap += fuzzyScoreGapExtension
+				}
+			} else if gap != none {
+				gap += fuzzyScoreGapExtension
+			}
+			if !f.equalRune(text[j], pattern[i]) {
+				continue
+			}
+			if i == 0 {
+				score[i][j] = fuzzyScoreMatch + bonus[j]*fuzzyBonusFirstChar
+				continue
+			}
+			if j > 0 && score[i-1][j-1] != none {
+				score[i][j] = score[i-1][j-1] + fuzzyScoreMatch + max(bonus[j], fuzzyBonusConsecutive)
+				from[i][j] = j - 1
+			}
+			if gap != none {
+				if s := gap + fuzzyScoreMatch + bonus[j]; s > score[i][j] {
+					score[i][j] = s
+					from[i][j] = gapFrom
+				}
+			}
+		}
+	}
+
+	best := -1
+	for j := range n {
+		if score[m-1][j] != none && (best < 0 || score[m-1][j] > score[m-1][best]) {
+			best = j
+		}
+	}
+	positions := make([]int, m)
+	for i := m - 1; i >= 0; i-- {
+		positions[i] = best
+		best = from[i][best]
+	}
+	return positions
+}
+
+// indexRune returns the index of the first rune that equals r from start.
+func (f fuzzyWord) indexRune(text []rune, start int, r rune) int {
+	for i := start; i < len(text); i++ {
+		if f.equalRune(text[i], r) {
+			return i
+		}
+	}
+	return -1
+}
+
+// equalRune reports whether the rune of the text equals the rune of the pattern.
+func (f fuzzyWord) equalRune(t rune, p rune) bool {
+	if f.caseSensitive {
+		return t == p
+	}
+	return t == p || unicode.ToLower(t) == p
+}
+
+// fuzzyScore returns the score of the matched positions.
+// Matches at word boundaries and consecutive matches get bonuses,
+// and gaps between the matches and typos get penalties.
+func fuzzyScore(target string, positions []int, typos int) int {
+	score := typos * fuzzyScoreTypo
+	prevEnd := -1
+	for n, pos := range positions {
+		r, size := utf8.DecodeRuneInString(target[pos:])
+		prev := ' '
+		if pos > 0 {
+			prev, _ = utf8.DecodeLastRuneInString(target[:pos])
+		}
+		s := fuzzyScoreMatch
+		bonus := fuzzyBonus(prev, r)
+		switch {
+		case n == 0:
+			bonus *= fuzzyBonusFirstChar
+		case prevEnd == pos:
+			bonus = max(bonus, fuzzyBonusConsecutive)
+		default:
+			gap := utf8.RuneCountInString(target[prevEnd:pos])
+			s += fuzzyScoreGapStart + (gap-1)*fuzzyScoreGapExtension
+		}
+		score += s + bonus
+		prevEnd = pos + size
+	}
+	return score
+}
+
+// fuzzyBonus returns the bonus of the character r after prev.
+func fuzzyBonus(prev rune, r rune) int {
+	switch {
+	case !isWordRune(prev) && isWordRune(r):
+		return fuzzyBonusBoundary
+	case unicode.IsLower(prev) && unicode.IsUpper(r):
+		return fuzzyBonusCamel
+	case !unicode.IsDigit(prev) && unicode.IsDigit(r):
+		return fuzzyBonusCamel
+	}
+	return 0
+}
+
+// isWordRune reports whether r is a letter or a digit.
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// scorer is a Searcher that can score the matched line.
+type scorer interface {
+	Searcher
+	score(target string) (int, bool)
+}
@@ -0,0 +1,174 @@
+package oviewer
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func Test_fuzzyWord_MatchString(t *testing.T) {
+	type args struct {
+		word          string
+		caseSensitive bool
+	}
+	tests := []struct {
+		name   string
+		args   args
+		target string
+		want   bool
+	}{
+		{
+			name:   "subsequence",
+			args:   args{word: "cnnrfsd"},
+			target: "error: connection refused",
+			want:   true,
+		},
+		{
+			name:   "order",
+			args:   args{word: "refcon"},
+			target: "connection refused",
+			want:   false,
+		},
+		{
+			name:   "typo",
+			args:   args{word: "connetcion"},
+			target: "connection refused",
+			want:   true,
+		},
+		{
+			name:   "tooManyTypos",
+			args:   args{word: "cxnnxxion"},
+			target: "connection refused",
+			want:   false,
+		},
+		{
+			name:   "shortNoTypo",
+			args:   args{word: "eror"},
+			target: "ERR",
+			want:   false,
+		},
+		{
+			name:   "ignoreCase",
+			args:   args{word: "timeout"},
+			target: "Read TimeOut",
+			want:   true,
+		},
+		{
+			name:   "caseSensitive",
+			args:   args{word: "timeout", caseSensitive: true},
+			target: "Read TimeOut",
+			want:   false,
+		},
+		{
+			name:   "multibyte",
+			args:   args{word: "日語"},
+			target: "日本語",
+			want:   true,
+		},
+		{
+			name:   "escapeSequence",
+			args:   args{word: "err"},
+			target: "\x1b[31me\x1b[mrr",
+			want:   true,
+		},
+		{
+			name:   "empty",
+			args:   args{word: ""},
+			target: "error",
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFuzzyWord(tt.args.word, tt.args.caseSensitive)
+			if got := f.MatchString(tt.target); got != tt.want {
+				t.Errorf("MatchString() = %v, want %v", got, tt.want)
+			}
+			if got := f.Match([]byte(tt.target)); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_fuzzyWord_FindAll(t *testing.T) {
+	tests := []struct {
+		name   string
+		word   string
+		target string
+		want   [][]int
+	}{
+		{
+			name:   "consecutive",
+			word:   "conref",
+			target: "connection refused",
+			want:   [][]int{{0, 3}, {11, 14}},
+		},
+		{
+			name:   "boundary",
+			word:   "ab",
+			target: "xa a b",
+			want:   [][]int{{3, 4}, {5, 6}},
+		},
+		{
+			name:   "multibyte",
+			word:   "日語",
+			target: "日本語",
+			want:   [][]int{{0, 3}, {6, 9}},
+		},
+		{
+			name:   "invalidUTF8",
+			word:   "abc",
+			target: "\xff\xffabc",
+			want:   [][]int{{2, 5}},
+		},
+		{
+			name:   "notMatch",
+			word:   "xyz",
+			target: "connection refused",
+			want:   nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFuzzyWord(tt.word, false)
+			if got := f.FindAll(tt.target); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindAll() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_fuzzyWord_score(t *testing.T) {
+	f := newFuzzyWord("conref", false)
+	better, ok := f.score("connection refused")
+	if !ok {
+		t.Fatal("score() not matched")
+	}
+	worse, ok := f.score("a cold winter of bureaucracy")
+	if !ok {
+		t.Fatal("score() not matched")
+	}
+	if better <= worse {
+		t.Errorf("score() = %v, want greater than %v", better, worse)
+	}
+	if _, ok := f.score("nothing"); ok {
+		t.Errorf("score() matched, want not matched")
+	}
+	// Invalid UTF-8 bytes are one byte each.
+	if _, ok := newFuzzyWord("abc", false).score("\xff\xff\xff\xffabc"); !ok {
+		t.Errorf("score() not matched")
+	}
+}
+
+func TestDocument_occurRank(t *testing.T) {
+	m := docHelper(t, "a cold winter of bureaucracy\nnot matched\nconnection refused\nconn ref\n")
+	lines := m.occurRank(context.Background(), newFuzzyWord("conref", false))
+	got := make([]int, 0, len(lines))
+	for _, l := range lines {
+		got = append(got, l.lineNum)
+	}
+	if want := []int{3, 2, 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("occurRank() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,353 @@
+package oviewer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"math"
+	"os"
+	"sync"
+	"unicode/utf8"
+)
+
+// searchIndexBits is the number of bits in the trigram set of a chunk.
+// Each chunk uses searchIndexBits/8 bytes (8 KiB) of memory.
+const searchIndexBits = 1 << 16
+
+// trigramSet is the set of hashed trigrams contained in a chunk.
+type trigramSet [searchIndexBits / 64]uint64
+
+// chunkTrigrams is the index of a chunk.
+type chunkTrigrams struct {
+	// set is nil if the chunk could not be indexed.
+	set *trigramSet
+	// lines is the number of lines indexed.
+	lines int
+	// last is true if the chunk contained the last line when indexed.
+	last bool
+	// size is the size of the store when indexed.
+	// The last line may be extended without adding lines.
+	size int64
+}
+
+// searchIndex is the trigram index of the document.
+// It records the trigrams of each chunk, so that searches can skip the chunks
+// that cannot contain the search word without reading them.
+// It is built in the background after loading, and extended when lines are added.
+type searchIndex struct {
+	mu sync.RWMutex
+	// cancel cancels the building.
+	cancel context.CancelFunc
+	// store is the store that the index was built from.
+	store *store
+	// chunks is the index of each chunk.
+	chunks []chunkTrigrams
+	// endNum is the number of lines when the index was last built.
+	endNum int
+	// size is the size of the store when the index was last built.
+	size int64
+	// building is true while building.
+	building bool
+}
+
+// trigramHash returns the bit of the trigram in trigramSet.
+func trigramHash(a byte, b byte, c byte) uint32 {
+	return (uint32(a)<<16 | uint32(b)<<8 | uint32(c)) * 0x9e3779b1 >> 16
+}
+
+// add adds the trigrams of the line to the set.
+// The line is lowercased so that the set can be used for case-insensitive searches.
+func (t *trigramSet) add(line []byte) {
+	line = bytes.ToLower(stripEscapeSequenceBytes(line))
+	for i := 0; i+3 <= len(line); i++ {
+		h := trigramHash(line[i], line[i+1], line[i+2])
+		t[h/64] |= 1 << (h % 64)
+	}
+}
+
+// contains returns true if the set contains all the hashes.
+func (t *trigramSet) contains(hashes []uint32) bool {
+	for _, h := range hashes {
+		if t[h/64]&(1<<(h%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// indexTrigrams returns the hashes of the trigrams that every line matching the searcher contains.
+// Only ASCII trigrams are used, because lowercasing other characters may change them.
+// It returns nil if the index cannot be used for the searcher.
+func indexTrigrams(searcher Searcher) []uint32 {
+	var word string
+	switch s := searcher.(type) {
+	case searchWord:
+		word = s.word
+	case sensitiveWord:
+		word = s.word
+	case regexpWord:
+		if s.regexp == nil {
+			return nil
+		}
+		word, _ = s.regexp.LiteralPrefix()
+	default:
+		return nil
+	}
+	var hashes []uint32
+	for i := 0; i+3 <= len(word); i++ {
+		a, b, c := word[i], word[i+1], word[i+2]
+		if a >= utf8.RuneSelf || b >= utf8.RuneSelf || c >= utf8.RuneSelf {
+			continue
+		}
+		hashes = append(hashes, trigramHash(lowerASCII(a), lowerASCII(b), lowerASCII(c)))
+	}
+	return hashes
+}
+
+// lowerASCII returns the lowercase of the ASCII character.
+func lowerASCII(c byte) byte {
+	if 'A' <= c && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+	return c
+}
+
+// mayContain returns false only if the index shows that the chunk does not contain all the hashes.
+func (idx *searchIndex) mayContain(s *store, endNum int, chunkNum int, hashes []uint32) bool {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	if idx.store != s || chunkNum < 0 || chunkNum >= len(idx.chunks) {
+		return true
+	}
+	c := idx.chunks[chunkNum]
+	if c.set == nil {
+		return true
+	}
+	// Lines may have been added to the last chunk after indexing.
+	if c.last && (c.size != s.contentSize() || c.lines != endNum-chunkNum*ChunkSize) {
+		return true
+	}
+	return c.set.contains(hashes)
+}
+
+// skipChunk returns true if the search index shows that no line in the chunk matches the searcher.
+func (m *Document) skipChunk(searcher Searcher, chunkNum int) bool {
+	if m.nonMatch {
+		return false
+	}
+	hashes := indexTrigrams(searcher)
+	if len(hashes) == 0 {
+		return false
+	}
+	return !m.searchIndex.mayContain(m.store, m.storeEndNum(), chunkNum, hashes)
+}
+
+// startSearchIndex builds the search index in the background after the document is loaded.
+// Only the chunks added or changed since the last build are indexed.
+func (m *Document) startSearchIndex(ctx context.Context) {
+	if !m.BufEOF() || m.checkClose() {
+		return
+	}
+	idx := &m.searchIndex
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	if idx.building {
+		return
+	}
+	s := m.store
+	if idx.store != s {
+		idx.store = s
+		idx.chunks = nil
+		idx.endNum = 0
+		idx.size = 0
+	}
+	endNum := m.storeEndNum()
+	size := s.contentSize()
+	if idx.endNum == endNum && idx.size == size {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	idx.cancel = cancel
+	idx.building = true
+	go m.buildSearchIndex(ctx, s, endNum, size)
+}
+
+// cancelSearchIndex cancels the building and clears the search index.
+func (m *Document) cancelSearchIndex() {
+	idx := &m.searchIndex
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	if idx.cancel != nil {
+		idx.cancel()
+	}
+	idx.store = nil
+	idx.chunks = nil
+	idx.endNum = 0
+	idx.size = 0
+	idx.building = false
+}
+
+// buildSearchIndex indexes the chunks of the store up to endNum.
+func (m *Document) buildSearchIndex(ctx context.Context, s *store, endNum int, size int64) {
+	idx := &m.searchIndex
+	defer func() {
+		idx.mu.Lock()
+		if idx.store == s {
+			idx.building = false
+		}
+		idx.mu.Unlock()
+	}()
+
+	idx.mu.RLock()
+	startChunk := len(idx.chunks)
+	// The last chunk may have been indexed halfway, so index it again.
+	if startChunk > 0 && idx.chunks[startChunk-1].last {
+		startChunk--
+	}
+	idx.mu.RUnlock()
+
+	f := m.chunkFile()
+	if f != nil {
+		defer f.Close()
+	}
+	lastChunk, _ := chunkLineNum(max(endNum-1, 0))
+	for chunkNum := startChunk; chunkNum <= lastChunk && endNum > 0; chunkNum++ {
+		if ctx.Err() != nil || m.checkClose() {
+			return
+		}
+		lines := min(ChunkSize, endNum-chunkNum*ChunkSize)
+		set := m.indexChunk(s, f, chunkNum, lines)
+		idx.mu.Lock()
+		if idx.store != s {
+			idx.mu.Unlock()
+			return
+		}
+		idx.chunks = append(idx.chunks[:min(chunkNum, len(idx.chunks))], chunkTrigrams{
+			set:   set,
+			lines: lines,
+			last:  chunkNum == lastChunk,
+			size:  size,
+		})
+		idx.mu.Unlock()
+	}
+
+	idx.mu.Lock()
+	if idx.store == s {
+		idx.endNum = endNum
+		idx.size = size
+	}
+	idx.mu.Unlock()
+}
+
+// indexChunk returns the trigram set of the lines in the chunk.
+// The chunk is read from memory, or from f if it is not in memory.
+// It returns nil if the lines cannot be read.
+func (m *Document) indexChunk(s *store, f *os.File, chunkNum int, lines int) *trigramSet {
+	set := new(trigramSet)
+	if s.isLoadedChunk(chunkNum, m.seekable) {
+		n := 0
+		for ; n < lines; n++ {
+			buf, err := s.GetChunkLine(chunkNum, n)
+			if err != nil {
+				break
+			}
+			set.add(buf)
+		}
+		if n == lines {
+			return set
+		}
+		// The chunk may have been evicted from memory.
+		set = new(trigramSet)
+	}
+	if f == nil {
+		return nil
+	}
+
+	start, ok := s.chunkStart(chunkNum)
+	if !ok {
+		return nil
+	}
+	reader := bufio.NewReader(io.NewSectionReader(f, start, math.MaxInt64-start))
+	count := 0
+	if err := readChunkLines(reader, func(n int, line []byte) bool {
+		if n >= lines {
+			return false
+		}
+		set.add(line)
+		count++
+		return true
+	}); err != nil {
+		log.Printf("search index: %v", err)
+		return nil
+	}
+	if count != lines {
+		return nil
+	}
+	return set
+}
+
+// chunkFile opens the file to read the chunks that are not in memory.
+// It returns nil if the document is not a seekable file.
+func (m *Document) chunkFile() *os.File {
+	if !m.seekable || m.FileName == "" || m.documentType != DocNormal {
+		return nil
+	}
+	f, err := os.Open(m.FileName)
+	if err != nil {
+		log.Printf("open %s: %v", m.FileName, err)
+		return nil
+	}
+	return f
+}
+
+// chunkLines calls fn for each line of the chunk until fn returns false.
+// The chunk is read from memory if it is loaded, otherwise from the file,
+// so that a document larger than the memory limit can be searched.
+func (m *Document) chunkLines(f *os.File, chunkNum int, fn func(n int, line []byte) bool) error {
+	s := m.store
+	if s.isLoadedChunk(chunkNum, m.seekable) {
+		if _, err := s.GetChunkLine(chunkNum, 0); err == nil {
+			for n := 0; n < ChunkSize; n++ {
+				line, err := s.GetChunkLine(chunkNum, n)
+				if err != nil || !fn(n, line) {
+					break
+				}
+			}
+			return nil
+		}
+		// The chunk may have been evicted from memory.
+	}
+	if f == nil {
+		return ErrOutOfRange
+	}
+	start, ok := s.chunkStart(chunkNum)
+	if !ok {
+		return ErrOutOfRange
+	}
+	reader := bufio.NewReader(io.NewSectionReader(f, start, math.MaxInt64-start))
+	endNum := m.storeEndNum()
+	return readChunkLines(reader, func(n int, line []byte) bool {
+		if chunkNum*ChunkSize+n >= endNum {
+			return false
+		}
+		return fn(n, line)
+	})
+}
+
+// contentSize returns the size of the lines read into the store.
+func (s *store) contentSize() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.size
+}
+
+// startSearchIndex builds the search index of the document if it is enabled.
+func (root *Root) startSearchIndex(ctx context.Context, m *Document) {
+	if !root.Config.SearchIndex || m == nil {
+		return
+	}
+	m.startSearchIndex(ctx)
+}
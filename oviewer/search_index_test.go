@@ -0,0 +1,115 @@
+package oviewer
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+)
+
+// waitSearchIndex builds the search index and waits for it to finish.
+func waitSearchIndex(t *testing.T, m *Document) {
+	t.Helper()
+	m.startSearchIndex(context.Background())
+	for range 500 {
+		m.searchIndex.mu.RLock()
+		building := m.searchIndex.building
+		m.searchIndex.mu.RUnlock()
+		if !building {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("search index is not built")
+}
+
+func Test_indexTrigrams(t *testing.T) {
+	tests := []struct {
+		name     string
+		searcher Searcher
+		want     int
+	}{
+		{name: "searchWord", searcher: NewSearcher("Error", nil, false, false), want: 3},
+		{name: "sensitiveWord", searcher: NewSearcher("Error", nil, true, false), want: 3},
+		{name: "short", searcher: NewSearcher("ab", nil, false, false), want: 0},
+		{name: "multibyte", searcher: NewSearcher("abc日本", nil, true, false), want: 1},
+		{name: "regexpPrefix", searcher: NewSearcher("error.*", regexp.MustCompile("error.*"), true, true), want: 3},
+		{name: "regexpNoPrefix", searcher: NewSearcher(".*error", regexp.MustCompile(".*error"), true, true), want: 0},
+		{name: "fuzzy", searcher: newFuzzyWord("error", false), want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := indexTrigrams(tt.searcher); len(got) != tt.want {
+				t.Errorf("indexTrigrams() = %v, want %d hashes", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_trigramSet(t *testing.T) {
+	set := new(trigramSet)
+	set.add([]byte("\x1b[31mCONNECTION\x1b[m refused"))
+	for _, word := range []string{"connection", "Connection", "refused", "TION REF"} {
+		if !set.contains(indexTrigrams(NewSearcher(word, nil, true, false))) {
+			t.Errorf("contains(%q) = false, want true", word)
+		}
+	}
+	if set.contains(indexTrigrams(NewSearcher("timeout", nil, false, false))) {
+		t.Errorf("contains(%q) = true, want false", "timeout")
+	}
+}
+
+func TestDocument_searchIndex(t *testing.T) {
+	m := parallelSearchFileHelper(t)
+	// The index of the chunk that is not in memory is built from the file.
+	m.store.unloadChunk(2)
+	waitSearchIndex(t, m)
+
+	target := NewSearcher("target", nil, false, false)
+	for cn, want := range []bool{true, true, false, true} {
+		if got := m.skipChunk(target, cn); got != want {
+			t.Errorf("skipChunk(%d) = %v, want %v", cn, got, want)
+		}
+	}
+	lN, err := m.SearchLine(context.Background(), target, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := ChunkSize*2 + 5; lN != want {
+		t.Errorf("SearchLine() = %v, want %v", lN, want)
+	}
+	lN, err = m.BackSearchLine(context.Background(), NewSearcher("back", nil, false, false), m.BufEndNum()-1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lN != 7 {
+		t.Errorf("BackSearchLine() = %v, want %v", lN, 7)
+	}
+
+	// Non-match search cannot skip chunks.
+	m.nonMatch = true
+	if m.skipChunk(target, 0) {
+		t.Errorf("skipChunk() with nonMatch = true, want false")
+	}
+	m.nonMatch = false
+
+	// The last chunk is not skipped until the added line is indexed.
+	lastChunk := m.store.lastChunkNum()
+	m.store.appendLine(m.store.chunks[lastChunk], []byte("target\n"))
+	if m.skipChunk(target, lastChunk) {
+		t.Errorf("skipChunk() after append = true, want false")
+	}
+	waitSearchIndex(t, m)
+	if m.skipChunk(target, lastChunk) {
+		t.Errorf("skipChunk() after indexing = true, want false")
+	}
+	if !m.skipChunk(target, 0) {
+		t.Errorf("skipChunk() of the unchanged chunk = false, want true")
+	}
+
+	// Reload discards the index.
+	m.reset()
+	if m.skipChunk(target, 0) {
+		t.Errorf("skipChunk() after reset = true, want false")
+	}
+}
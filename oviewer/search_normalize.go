@@ -0,0 +1,162 @@
+package oviewer
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// searchNormalizer normalizes the search word and the lines,
+// so that the search ignores differences in Unicode normalization (and diacritics).
+type searchNormalizer struct {
+	// form is the normalization form of the result.
+	form norm.Form
+	// foldDiacritics removes the diacritical marks.
+	foldDiacritics bool
+	// foldCase lowercases the lines for the case-insensitive search.
+	// Lowercasing may change the length (e.g. "İ" to "i̇"),
+	// so it is done here to map the positions of the match back to the original.
+	foldCase bool
+}
+
+// newSearchNormalizer returns the searchNormalizer of the normalization form ("NFC" or "NFKC").
+// It returns nil if no normalization is needed.
+func newSearchNormalizer(form string, foldDiacritics bool, foldCase bool) *searchNormalizer {
+	n := &searchNormalizer{form: norm.NFC, foldDiacritics: foldDiacritics, foldCase: foldCase}
+	switch strings.ToUpper(form) {
+	case "NFC":
+	case "NFKC":
+		n.form = norm.NFKC
+	default:
+		if !foldDiacritics {
+			return nil
+		}
+	}
+	return n
+}
+
+// normalize returns the normalized string and the original positions of each byte of it.
+// starts and ends are the start and end of the original segment that produced the byte.
+// They are nil if the string is not changed (ASCII only).
+func (n *searchNormalizer) normalize(s string) (string, []int, []int) {
+	if isASCII(s) {
+		return s, nil, nil
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	starts := make([]int, 0, len(s))
+	ends := make([]int, 0, len(s))
+	for i := 0; i < len(s); {
+		// Segments between boundaries are normalized independently.
+		j := i + n.form.NextBoundaryInString(s[i:], true)
+		if j <= i {
+			_, size := utf8.DecodeRuneInString(s[i:])
+			j = i + size
+		}
+		seg := n.segment(s[i:j])
+		b.WriteString(seg)
+		for range len(seg) {
+			starts = append(starts, i)
+			ends = append(ends, j)
+		}
+		i = j
+	}
+	return b.String(), starts, ends
+}
+
+// segment returns the normalized segment.
+func (n *searchNormalizer) segment(seg string) string {
+	if n.foldCase {
+		seg = strings.ToLower(seg)
+	}
+	if !n.foldDiacritics {
+		return n.form.String(seg)
+	}
+	decomposed := norm.NFD
+	if n.form == norm.NFKC {
+		decomposed = norm.NFKD
+	}
+	seg = strings.Map(func(r rune) rune {
+		if unicode.Is(unicode.Mn, r) {
+			return -1
+		}
+		return r
+	}, decomposed.String(seg))
+	return n.form.String(seg)
+}
+
+// isASCII returns true if s contains only ASCII characters.
+func isASCII(s string) bool {
+	for i := range len(s) {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizedWord is a Searcher that searches the normalized lines with the searcher of the normalized word.
+type normalizedWord struct {
+	word       string
+	searcher   Searcher
+	normalizer *searchNormalizer
+}
+
+// newNormalizedWord returns the normalizedWord.
+// newSearcher returns the Searcher of the normalized word.
+func newNormalizedWord(word string, n *searchNormalizer, newSearcher func(string) Searcher) normalizedWord {
+	// The case of the word is handled by the searcher (a regular expression must not be lowercased).
+	wordNormalizer := *n
+	wordNormalizer.foldCase = false
+	normalized, _, _ := wordNormalizer.normalize(word)
+	return normalizedWord{
+		word:       word,
+		searcher:   newSearcher(normalized),
+		normalizer: n,
+	}
+}
+
+// normalizedWord Match searches the normalized bytes.
+func (substr normalizedWord) Match(target []byte) bool {
+	return substr.MatchString(string(target))
+}
+
+// normalizedWord MatchString searches the normalized string.
+func (substr normalizedWord) MatchString(target string) bool {
+	normalized, _, _ := substr.normalizer.normalize(target)
+	return substr.searcher.MatchString(normalized)
+}
+
+// normalizedWord FindAll searches the normalized string and returns the index of the match in the original string.
+func (substr normalizedWord) FindAll(target string) [][]int {
+	normalized, starts, ends := substr.normalizer.normalize(target)
+	indexes := substr.searcher.FindAll(normalized)
+	if starts == nil {
+		return indexes
+	}
+	for _, idx := range indexes {
+		start, end := idx[0], idx[1]
+		idx[0] = originalPos(starts, len(target), start)
+		if end > start {
+			idx[1] = ends[end-1]
+		} else {
+			idx[1] = idx[0]
+		}
+	}
+	return indexes
+}
+
+// originalPos returns the original position of the normalized position.
+func originalPos(starts []int, length int, pos int) int {
+	if pos >= len(starts) {
+		return length
+	}
+	return starts[pos]
+}
+
+// normalizedWord String returns the search word.
+func (substr normalizedWord) String() string {
+	return substr.word
+}
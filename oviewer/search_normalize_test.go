@@ -0,0 +1,160 @@
+package oviewer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_searchNormalizer_normalize(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name           string
+		form           string
+		foldDiacritics bool
+		s              string
+		want           string
+	}{
+		{name: "ascii", form: "NFC", s: "resume", want: "resume"},
+		{name: "NFDtoNFC", form: "NFC", s: "re\u0301sume\u0301", want: "résumé"},
+		{name: "NFKC", form: "NFKC", s: "ﬁle", want: "file"},
+		{name: "NFCKeepsLigature", form: "NFC", s: "ﬁle", want: "ﬁle"},
+		{name: "fold", foldDiacritics: true, s: "résumé", want: "resume"},
+		{name: "foldNFD", foldDiacritics: true, s: "re\u0301sume\u0301", want: "resume"},
+		{name: "foldKeepsOthers", foldDiacritics: true, s: "日本語", want: "日本語"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			n := newSearchNormalizer(tt.form, tt.foldDiacritics, false)
+			got, _, _ := n.normalize(tt.s)
+			if got != tt.want {
+				t.Errorf("normalize() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_newSearchNormalizer(t *testing.T) {
+	t.Parallel()
+	if n := newSearchNormalizer("", false, false); n != nil {
+		t.Errorf("newSearchNormalizer() = %v, want nil", n)
+	}
+	if n := newSearchNormalizer("nfkc", false, false); n == nil {
+		t.Errorf("newSearchNormalizer() = nil, want NFKC")
+	}
+}
+
+func Test_normalizedWord_FindAll(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name           string
+		word           string
+		form           string
+		foldDiacritics bool
+		target         string
+		want           [][]int
+		wantMatch      bool
+	}{
+		{
+			name:           "accent",
+			word:           "resume",
+			foldDiacritics: true,
+			target:         "my résumé.pdf",
+			want:           [][]int{{3, 11}},
+			wantMatch:      true,
+		},
+		{
+			name:      "NFD",
+			word:      "résumé",
+			form:      "NFC",
+			target:    "a re\u0301sume\u0301",
+			want:      [][]int{{2, 12}},
+			wantMatch: true,
+		},
+		{
+			name:      "partOfSegment",
+			word:      "f",
+			form:      "NFKC",
+			target:    "ﬁle",
+			want:      [][]int{{0, 3}},
+			wantMatch: true,
+		},
+		{
+			name:      "ascii",
+			word:      "err",
+			form:      "NFC",
+			target:    "an error",
+			want:      [][]int{{3, 6}},
+			wantMatch: true,
+		},
+		{
+			name:      "lengthChangingFold",
+			word:      "x",
+			form:      "NFC",
+			target:    "İİİİx",
+			want:      [][]int{{8, 9}},
+			wantMatch: true,
+		},
+		{
+			name:      "matchLengthChangingFold",
+			word:      "İx",
+			form:      "NFC",
+			target:    "aİxİ",
+			want:      [][]int{{1, 4}},
+			wantMatch: true,
+		},
+		{
+			name:           "foldCaseAndDiacritics",
+			word:           "resume",
+			foldDiacritics: true,
+			target:         "İ RÉSUMÉ",
+			want:           [][]int{{3, 11}},
+			wantMatch:      true,
+		},
+		{
+			name:      "noFold",
+			word:      "resume",
+			form:      "NFC",
+			target:    "résumé",
+			want:      nil,
+			wantMatch: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			n := newSearchNormalizer(tt.form, tt.foldDiacritics, true)
+			s := newNormalizedWord(tt.word, n, func(word string) Searcher {
+				return NewSearcher(word, nil, false, false)
+			})
+			if got := s.MatchString(tt.target); got != tt.wantMatch {
+				t.Errorf("MatchString() = %v, want %v", got, tt.wantMatch)
+			}
+			if got := s.Match([]byte(tt.target)); got != tt.wantMatch {
+				t.Errorf("Match() = %v, want %v", got, tt.wantMatch)
+			}
+			if got := s.FindAll(tt.target); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindAll() = %v, want %v", got, tt.want)
+			}
+			if got := s.String(); got != tt.word {
+				t.Errorf("String() = %v, want %v", got, tt.word)
+			}
+		})
+	}
+}
+
+func TestRoot_newSearcherNormalized(t *testing.T) {
+	root := rootHelper(t)
+	root.Config.IgnoreDiacritics = true
+	searcher := root.newSearcher("resume", false)
+	if _, ok := searcher.(normalizedWord); !ok {
+		t.Fatalf("newSearcher() = %T, want normalizedWord", searcher)
+	}
+	if !searcher.MatchString("RÉSUMÉ") {
+		t.Errorf("MatchString() = false, want true")
+	}
+	root.Config.IgnoreDiacritics = false
+	if _, ok := root.newSearcher("resume", false).(normalizedWord); ok {
+		t.Errorf("newSearcher() = normalizedWord, want the plain searcher")
+	}
+}
@@ -0,0 +1,221 @@
+package oviewer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math"
+	"os"
+	"runtime"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// parallelSearchWorkers is the number of chunks searched at the same time.
+// Reading the file also takes time, so at least two chunks are searched even with one CPU.
+var parallelSearchWorkers = min(max(runtime.NumCPU(), 2), 8)
+
+// parallelSearchMinChunks is the minimum number of chunks to search in parallel.
+const parallelSearchMinChunks = 2
+
+// chunkResult is the result of searching a chunk.
+type chunkResult struct {
+	n   int
+	err error
+}
+
+// parallelSearchFile opens the file to read the chunks that are not in memory.
+// It returns nil if the chunks cannot be searched in parallel.
+func (m *Document) parallelSearchFile(chunks int) *os.File {
+	if !m.seekable || parallelSearchWorkers < 2 || chunks < parallelSearchMinChunks {
+		return nil
+	}
+	return m.chunkFile()
+}
+
+// parallelSearch searches the chunks concurrently and returns the line number of the first match
+// in the order of chunks. lineNum is the line in the chunk to start searching
+// (for a backward search, the last line of the chunk).
+// Chunks in memory are searched in memory, and other chunks are read from f
+// without being loaded into memory.
+func (m *Document) parallelSearch(ctx context.Context, f *os.File, searcher Searcher, forward bool, chunks []int, lineNum int) (int, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	eg := new(errgroup.Group)
+	eg.SetLimit(parallelSearchWorkers)
+	// window limits how far ahead of the nearest chunk to search.
+	window := make(chan struct{}, parallelSearchWorkers*2)
+	results := make([]chan chunkResult, len(chunks))
+	for i := range results {
+		results[i] = make(chan chunkResult, 1)
+	}
+
+	fed := make(chan struct{})
+	go func() {
+		defer close(fed)
+		for i, cn := range chunks {
+			select {
+			case window <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			eg.Go(func() error {
+				n, err := m.searchChunkParallel(ctx, f, searcher, forward, cn, lineNum)
+				results[i] <- chunkResult{n: n, err: err}
+				return nil
+			})
+		}
+	}()
+	defer func() {
+		cancel()
+		<-fed
+		_ = eg.Wait()
+	}()
+
+	for i, cn := range chunks {
+		var r chunkResult
+		select {
+		case r = <-results[i]:
+		case <-ctx.Done():
+			return 0, ErrCancel
+		}
+		<-window
+		progressFromContext(ctx).update(cn)
+		if r.err == nil {
+			if !m.store.isLoadedChunk(cn, m.seekable) {
+				m.requestLoadSync(cn)
+			}
+			return cn*ChunkSize + r.n, nil
+		}
+		if errors.Is(r.err, ErrCancel) {
+			return 0, ErrCancel
+		}
+	}
+	return 0, ErrNotFound
+}
+
+// searchChunkParallel searches a chunk in memory or in the file.
+func (m *Document) searchChunkParallel(ctx context.Context, f *os.File, searcher Searcher, forward bool, chunkNum int, lineNum int) (int, error) {
+	if m.skipChunk(searcher, chunkNum) {
+		return 0, ErrNotFound
+	}
+	if m.store.isLoadedChunk(chunkNum, m.seekable) {
+		n, err := m.searchChunkMem(ctx, searcher, forward, chunkNum, lineNum)
+		if err == nil || errors.Is(err, ErrCancel) || errors.Is(err, ErrNotFound) {
+			return n, err
+		}
+		// The chunk may have been evicted from memory.
+	}
+	return m.searchChunkFile(ctx, f, searcher, forward, chunkNum, lineNum)
+}
+
+// searchChunkMem searches a chunk in memory.
+func (m *Document) searchChunkMem(ctx context.Context, searcher Searcher, forward bool, chunkNum int, lineNum int) (int, error) {
+	switch {
+	case forward && m.nonMatch:
+		return m.SearchChunkNonMatch(ctx, searcher, chunkNum, lineNum)
+	case forward:
+		return m.SearchChunk(ctx, searcher, chunkNum, lineNum)
+	case m.nonMatch:
+		return m.BackSearchChunkNonMatch(ctx, searcher, chunkNum, lineNum)
+	default:
+		return m.BackSearchChunk(ctx, searcher, chunkNum, lineNum)
+	}
+}
+
+// searchChunkFile searches a chunk by reading the file.
+// Forward search returns the first matching line from lineNum,
+// and backward search returns the last matching line up to lineNum.
+func (m *Document) searchChunkFile(ctx context.Context, f *os.File, searcher Searcher, forward bool, chunkNum int, lineNum int) (int, error) {
+	start, ok := m.store.chunkStart(chunkNum)
+	if !ok {
+		return 0, ErrOutOfChunk
+	}
+	reader := bufio.NewReader(io.NewSectionReader(f, start, math.MaxInt64-start))
+	found := -1
+	err := readChunkLines(reader, func(n int, line []byte) bool {
+		select {
+		case <-ctx.Done():
+			return false
+		default:
+		}
+		if forward && n < lineNum {
+			return true
+		}
+		if !forward && n > lineNum {
+			return false
+		}
+		if searcher.Match(line) != m.nonMatch {
+			found = n
+			return !forward
+		}
+		return true
+	})
+	if ctx.Err() != nil {
+		return 0, ErrCancel
+	}
+	if found >= 0 {
+		return found, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return 0, ErrNotFound
+}
+
+// chunkStart returns the position in the file where the chunk starts.
+func (s *store) chunkStart(chunkNum int) (int64, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if chunkNum < 0 || chunkNum >= len(s.chunks) {
+		return 0, false
+	}
+	return s.chunks[chunkNum].start, true
+}
+
+// readChunkLines reads the lines of a chunk (up to ChunkSize lines) and calls fn for each line.
+// The newline at the end of the line is removed. Reading stops when fn returns false.
+func readChunkLines(reader *bufio.Reader, fn func(n int, line []byte) bool) error {
+	var line bytes.Buffer
+	for num := 0; num < ChunkSize; {
+		buf, err := reader.ReadSlice('\n')
+		if errors.Is(err, bufio.ErrBufferFull) {
+			line.Write(buf)
+			continue
+		}
+		line.Write(buf)
+		if line.Len() > 0 {
+			if !fn(num, bytes.TrimSuffix(line.Bytes(), []byte("\n"))) {
+				return nil
+			}
+		}
+		num++
+		line.Reset()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// forwardChunks returns the chunk numbers from start to end.
+func forwardChunks(start int, end int) []int {
+	chunks := make([]int, 0, max(end-start+1, 0))
+	for cn := start; cn <= end; cn++ {
+		chunks = append(chunks, cn)
+	}
+	return chunks
+}
+
+// backwardChunks returns the chunk numbers from start down to end.
+func backwardChunks(start int, end int) []int {
+	chunks := make([]int, 0, max(start-end+1, 0))
+	for cn := start; cn >= end; cn-- {
+		chunks = append(chunks, cn)
+	}
+	return chunks
+}
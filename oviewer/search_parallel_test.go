@@ -0,0 +1,143 @@
+package oviewer
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// parallelSearchFileHelper writes a file of more than three chunks and opens it.
+// "target" is at line 5 of chunk 2, and "back" is at line 7 of chunk 0.
+func parallelSearchFileHelper(t *testing.T) *Document {
+	t.Helper()
+	var b strings.Builder
+	for n := range ChunkSize*3 + 10 {
+		switch n {
+		case ChunkSize*2 + 5:
+			b.WriteString("target\n")
+		case 7:
+			b.WriteString("back\n")
+		default:
+			fmt.Fprintf(&b, "line %d\n", n)
+		}
+	}
+	fileName := filepath.Join(t.TempDir(), "large.txt")
+	if err := os.WriteFile(fileName, []byte(b.String()), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return docFileReadHelper(t, fileName)
+}
+
+func TestDocument_parallelSearchLine(t *testing.T) {
+	m := parallelSearchFileHelper(t)
+	// Search the chunk that is not in memory from the file.
+	m.store.unloadChunk(2)
+
+	lN, err := m.SearchLine(context.Background(), NewSearcher("target", nil, false, false), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := ChunkSize*2 + 5; lN != want {
+		t.Errorf("SearchLine() = %v, want %v", lN, want)
+	}
+	if !m.store.isLoadedChunk(2, m.seekable) {
+		t.Errorf("the found chunk is not loaded")
+	}
+
+	lN, err = m.BackSearchLine(context.Background(), NewSearcher("back", nil, false, false), m.BufEndNum()-1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lN != 7 {
+		t.Errorf("BackSearchLine() = %v, want %v", lN, 7)
+	}
+
+	if _, err := m.SearchLine(context.Background(), NewSearcher("nothing", nil, false, false), 0); !errors.Is(err, ErrNotFound) {
+		t.Errorf("SearchLine() error = %v, want %v", err, ErrNotFound)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := m.SearchLine(ctx, NewSearcher("nothing", nil, false, false), 0); !errors.Is(err, ErrCancel) {
+		t.Errorf("SearchLine() error = %v, want %v", err, ErrCancel)
+	}
+}
+
+func TestDocument_searchChunkFile(t *testing.T) {
+	m := parallelSearchFileHelper(t)
+	f, err := os.Open(m.FileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	tests := []struct {
+		name     string
+		word     string
+		forward  bool
+		nonMatch bool
+		chunkNum int
+		lineNum  int
+		want     int
+		wantErr  error
+	}{
+		{name: "forward", word: "target", forward: true, chunkNum: 2, lineNum: 0, want: 5},
+		{name: "forwardAfter", word: "target", forward: true, chunkNum: 2, lineNum: 6, wantErr: ErrNotFound},
+		{name: "backward", word: "line", forward: false, chunkNum: 1, lineNum: ChunkSize - 1, want: ChunkSize - 1},
+		{name: "backwardBefore", word: "back", forward: false, chunkNum: 0, lineNum: 6, wantErr: ErrNotFound},
+		{name: "nonMatch", word: "line", forward: true, nonMatch: true, chunkNum: 0, lineNum: 0, want: 7},
+		{name: "lastChunk", word: fmt.Sprintf("line %d", ChunkSize*3+9), forward: true, chunkNum: 3, lineNum: 0, want: 9},
+		{name: "outOfChunk", word: "line", forward: true, chunkNum: 4, lineNum: 0, wantErr: ErrOutOfChunk},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m.nonMatch = tt.nonMatch
+			got, err := m.searchChunkFile(context.Background(), f, NewSearcher(tt.word, nil, false, false), tt.forward, tt.chunkNum, tt.lineNum)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("searchChunkFile() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("searchChunkFile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_readChunkLines(t *testing.T) {
+	long := strings.Repeat("x", 40)
+	reader := bufio.NewReaderSize(strings.NewReader("a\n"+long+"\n\nlast"), 16)
+	var got []string
+	if err := readChunkLines(reader, func(_ int, line []byte) bool {
+		got = append(got, string(line))
+		return true
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"a", long, "", "last"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("readChunkLines() = %q, want %q", got, want)
+	}
+}
+
+func Test_forwardBackwardChunks(t *testing.T) {
+	if got, want := forwardChunks(2, 4), []int{2, 3, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("forwardChunks() = %v, want %v", got, want)
+	}
+	if got, want := backwardChunks(4, 2), []int{4, 3, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("backwardChunks() = %v, want %v", got, want)
+	}
+	if got := forwardChunks(3, 2); len(got) != 0 {
+		t.Errorf("forwardChunks() = %v, want empty", got)
+	}
+}
@@ -0,0 +1,173 @@
+package oviewer
+
+import (
+	"bytes"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// rawWord is a Searcher that searches the original bytes of the line,
+// including escape sequences and invalid UTF-8.
+type rawWord struct {
+	word string
+	// raw is the unescaped word, lowercased if case-insensitive.
+	raw           []byte
+	caseSensitive bool
+	// regexp is nil if the word is not a regular expression.
+	regexp *regexp.Regexp
+}
+
+// newRawWord returns the rawWord.
+// `\xHH`, `\e`, `\t`, `\r` and `\\` in the word are unescaped.
+func newRawWord(word string, caseSensitive bool, regexpSearch bool) rawWord {
+	s := rawWord{
+		word:          word,
+		caseSensitive: caseSensitive,
+	}
+	if regexpSearch && word != regexp.QuoteMeta(word) {
+		opt := ""
+		if !caseSensitive {
+			opt = "(?i)"
+		}
+		// Go regular expressions interpret `\xHH` and `\t` themselves.
+		re, err := regexp.Compile(opt + strings.ReplaceAll(word, `\e`, `\x1b`))
+		if err == nil {
+			s.regexp = re
+			return s
+		}
+		log.Printf("raw search: %v", err)
+	}
+	s.raw = unescapeRaw(word)
+	if !caseSensitive {
+		s.raw = lowerASCIIBytes(s.raw)
+	}
+	return s
+}
+
+// unescapeRaw returns the bytes of the word with the escapes replaced.
+// Unknown escapes are left as they are.
+func unescapeRaw(word string) []byte {
+	buf := make([]byte, 0, len(word))
+	for i := 0; i < len(word); i++ {
+		c := word[i]
+		if c != '\\' || i+1 >= len(word) {
+			buf = append(buf, c)
+			continue
+		}
+		switch word[i+1] {
+		case 'x':
+			if i+4 <= len(word) {
+				if b, err := strconv.ParseUint(word[i+2:i+4], 16, 8); err == nil {
+					buf = append(buf, byte(b))
+					i += 3
+					continue
+				}
+			}
+			buf = append(buf, c)
+		case 'e':
+			buf = append(buf, 0x1b)
+			i++
+		case 't':
+			buf = append(buf, '\t')
+			i++
+		case 'r':
+			buf = append(buf, '\r')
+			i++
+		case '\\':
+			buf = append(buf, '\\')
+			i++
+		default:
+			buf = append(buf, c)
+		}
+	}
+	return buf
+}
+
+// lowerASCIIBytes returns a copy of b with the ASCII characters lowercased.
+// Unlike bytes.ToLower, it does not change invalid UTF-8.
+func lowerASCIIBytes(b []byte) []byte {
+	lower := make([]byte, len(b))
+	for i, c := range b {
+		lower[i] = lowerASCII(c)
+	}
+	return lower
+}
+
+// rawWord Match searches the original bytes.
+func (substr rawWord) Match(target []byte) bool {
+	if substr.regexp != nil {
+		return substr.regexp.Match(target)
+	}
+	if len(substr.raw) == 0 {
+		return false
+	}
+	if !substr.caseSensitive {
+		target = lowerASCIIBytes(target)
+	}
+	return bytes.Contains(target, substr.raw)
+}
+
+// rawWord MatchString searches the original string.
+func (substr rawWord) MatchString(target string) bool {
+	return substr.Match([]byte(target))
+}
+
+// rawWord FindAll searches the original string and returns the index of the match.
+func (substr rawWord) FindAll(target string) [][]int {
+	if substr.regexp != nil {
+		return substr.regexp.FindAllStringIndex(target, -1)
+	}
+	if len(substr.raw) == 0 {
+		return nil
+	}
+	b := []byte(target)
+	if !substr.caseSensitive {
+		b = lowerASCIIBytes(b)
+	}
+	var indexes [][]int
+	for offset := 0; ; {
+		pos := bytes.Index(b[offset:], substr.raw)
+		if pos < 0 {
+			break
+		}
+		start := offset + pos
+		offset = start + len(substr.raw)
+		indexes = append(indexes, []int{start, offset})
+	}
+	return indexes
+}
+
+// rawWord String returns the search word.
+func (substr rawWord) String() string {
+	return substr.word
+}
+
+// rawSearchPos returns the positions of the contents that match the rawWord in the original line.
+func (m *Document) rawSearchPos(lN int, searcher rawWord) [][]int {
+	str, err := m.LineStr(lN)
+	if err != nil {
+		return nil
+	}
+	indexes := searcher.FindAll(str)
+	if len(indexes) == 0 {
+		return nil
+	}
+	starts, ends := contentsPos(m.conv, str, m.TabWidth)
+	for _, idx := range indexes {
+		idx[0], idx[1] = starts[idx[0]], ends[idx[1]]
+	}
+	return indexes
+}
+
+// rawSearchHighlight applies the style of the search highlight to the matches of the raw search.
+func (root *Root) rawSearchHighlight(lN int, lineC LineC) {
+	searcher, ok := root.searcher.(rawWord)
+	if !ok {
+		return
+	}
+	for _, idx := range root.Doc.rawSearchPos(lN, searcher) {
+		RangeStyle(lineC.lc, idx[0], idx[1], root.Doc.Style.SearchHighlight)
+	}
+}
@@ -0,0 +1,161 @@
+package oviewer
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func Test_unescapeRaw(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		word string
+		want []byte
+	}{
+		{name: "plain", word: "error", want: []byte("error")},
+		{name: "hex", word: `\x1b[31m`, want: []byte("\x1b[31m")},
+		{name: "nul", word: `a\x00b`, want: []byte("a\x00b")},
+		{name: "invalidUTF8", word: `\xff`, want: []byte{0xff}},
+		{name: "esc", word: `\e[0m`, want: []byte("\x1b[0m")},
+		{name: "tab", word: `a\tb`, want: []byte("a\tb")},
+		{name: "backslash", word: `a\\x1b`, want: []byte(`a\x1b`)},
+		{name: "invalidHex", word: `\xzz`, want: []byte(`\xzz`)},
+		{name: "short", word: `\x1`, want: []byte(`\x1`)},
+		{name: "unknown", word: `\d`, want: []byte(`\d`)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := unescapeRaw(tt.word); !bytes.Equal(got, tt.want) {
+				t.Errorf("unescapeRaw() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_rawWord(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		word          string
+		caseSensitive bool
+		regexpSearch  bool
+		target        string
+		wantMatch     bool
+		want          [][]int
+	}{
+		{
+			name:      "escapeSequence",
+			word:      `\x1b[31m`,
+			target:    "a \x1b[31mred\x1b[m \x1b[31mred",
+			wantMatch: true,
+			want:      [][]int{{2, 7}, {14, 19}},
+		},
+		{
+			name:      "notStripped",
+			word:      "ared",
+			target:    "a\x1b[31mred",
+			wantMatch: false,
+		},
+		{
+			name:      "invalidUTF8",
+			word:      `\xff\xfe`,
+			target:    "ab\xff\xfecd",
+			wantMatch: true,
+			want:      [][]int{{2, 4}},
+		},
+		{
+			name:      "caseInsensitive",
+			word:      "ERROR",
+			target:    "\xffError",
+			wantMatch: true,
+			want:      [][]int{{1, 6}},
+		},
+		{
+			name:          "caseSensitive",
+			word:          "ERROR",
+			caseSensitive: true,
+			target:        "Error",
+			wantMatch:     false,
+		},
+		{
+			name:         "regexp",
+			word:         `\e\[3[0-7]m`,
+			regexpSearch: true,
+			target:       "x\x1b[32mgreen",
+			wantMatch:    true,
+			want:         [][]int{{1, 6}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s := newRawWord(tt.word, tt.caseSensitive, tt.regexpSearch)
+			if got := s.Match([]byte(tt.target)); got != tt.wantMatch {
+				t.Errorf("Match() = %v, want %v", got, tt.wantMatch)
+			}
+			if got := s.MatchString(tt.target); got != tt.wantMatch {
+				t.Errorf("MatchString() = %v, want %v", got, tt.wantMatch)
+			}
+			if got := s.FindAll(tt.target); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindAll() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_contentsPos(t *testing.T) {
+	t.Parallel()
+	str := "a\x1b[31mb\tあ"
+	starts, ends := contentsPos(newESConverter(), str, 4)
+	// "a" is 0, the escape sequence has no contents, "b" is 1, the tab is 2-3 and "あ" is 4-5.
+	wantStarts := []int{0, 1, 1, 1, 1, 1, 1, 2, 4, 4, 4, 6}
+	wantEnds := []int{0, 1, 1, 1, 1, 1, 1, 2, 4, 6, 6, 6}
+	if !reflect.DeepEqual(starts, wantStarts) {
+		t.Errorf("contentsPos() starts = %v, want %v", starts, wantStarts)
+	}
+	if !reflect.DeepEqual(ends, wantEnds) {
+		t.Errorf("contentsPos() ends = %v, want %v", ends, wantEnds)
+	}
+}
+
+func TestRoot_rawSearch(t *testing.T) {
+	tcellNewScreen = fakeScreen
+	defer func() {
+		tcellNewScreen = tcell.NewScreen
+	}()
+	root, err := NewRoot(bytes.NewBufferString("plain red\nok \x1b[31mred\x1b[m\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	root.Doc.WaitEOF()
+	root.prepareScreen()
+	root.Config.RawSearch = true
+	root.searcher = root.newSearcher(`\x1b[31mred`, false)
+	if _, ok := root.searcher.(rawWord); !ok {
+		t.Fatalf("newSearcher() = %T, want rawWord", root.searcher)
+	}
+	if root.searcher.Match([]byte("plain red")) {
+		t.Errorf("Match() = true, want false")
+	}
+
+	// "red" after the escape sequence is highlighted.
+	if got, want := root.Doc.rawSearchPos(1, root.searcher.(rawWord)), [][]int{{3, 6}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("rawSearchPos() = %v, want %v", got, want)
+	}
+	lineC := root.lineContent(1)
+	highlighted := func(style tcell.Style) bool {
+		return style == applyStyle(style, root.Doc.Style.SearchHighlight)
+	}
+	for x, want := range []bool{false, false, false, true, true, true} {
+		if got := highlighted(lineC.lc[x].style); got != want {
+			t.Errorf("highlighted(%d) = %v, want %v", x, got, want)
+		}
+	}
+	if x1, x2 := root.searchXPos(1, root.searcher); x1 != 3 || x2 != 6 {
+		t.Errorf("searchXPos() = %d, %d, want 3, 6", x1, x2)
+	}
+}
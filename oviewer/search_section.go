@@ -0,0 +1,177 @@
+package oviewer
+
+import (
+	"context"
+	"errors"
+	"log"
+)
+
+// sectionRange returns the first line of the section containing lN and the first line of the next section.
+// The lines before the first section delimiter are treated as a section.
+func (m *Document) sectionRange(ctx context.Context, lN int) (int, int) {
+	searcher := m.sectionDelimiterSearcher()
+	pos := m.SectionStartPosition
+	start := m.firstLine()
+	if n, err := m.BackSearchLine(ctx, searcher, lN+pos); err == nil {
+		start = max(n-pos, start)
+	}
+	end := m.BufEndNum()
+	if n, err := m.SearchLine(ctx, searcher, lN+pos+1); err == nil {
+		end = max(n-pos, lN+1)
+	}
+	return start, end
+}
+
+// searchScope returns the range of lines to search.
+// If section search is enabled, it is the section of the current position (see startSearchLN).
+// Otherwise, end is -1 and the whole document is searched.
+func (root *Root) searchScope(ctx context.Context) (int, int) {
+	m := root.Doc
+	if !root.Config.SectionSearch || m.SectionDelimiter == "" {
+		return 0, -1
+	}
+	return m.sectionRange(ctx, max(root.startSearchLN(), m.firstLine()))
+}
+
+// searchLineRange searches forward/backward from lineNum in the range of start to end (exclusive).
+// If end is negative, the whole document is searched.
+func (m *Document) searchLineRange(ctx context.Context, searcher Searcher, forward bool, lineNum int, start int, end int) (int, error) {
+	if end < 0 {
+		return m.searchLine(ctx, searcher, forward, lineNum)
+	}
+	if forward {
+		lineNum = max(lineNum, start)
+	} else {
+		lineNum = min(lineNum, end-1)
+	}
+	if lineNum < start || lineNum >= end {
+		return 0, ErrNotFound
+	}
+	if forward {
+		return m.searchRangeForward(ctx, searcher, lineNum, end)
+	}
+	return m.searchRangeBackward(ctx, searcher, lineNum, start)
+}
+
+// searchRangeForward searches forward from lineNum to end (exclusive).
+// Only the lines in the range are searched.
+func (m *Document) searchRangeForward(ctx context.Context, searcher Searcher, lineNum int, end int) (int, error) {
+	startChunk, sn := chunkLineNum(lineNum)
+	endChunk, endN := chunkLineNum(end - 1)
+	defer m.startProgress(ctx, startChunk, endChunk, true)()
+	for cn := startChunk; cn <= endChunk; cn++ {
+		en := ChunkSize - 1
+		if cn == endChunk {
+			en = endN
+		}
+		n, err := m.searchChunkRange(ctx, searcher, cn, sn, en)
+		if err == nil {
+			return cn*ChunkSize + n, nil
+		}
+		if errors.Is(err, ErrCancel) {
+			return 0, err
+		}
+		sn = 0
+	}
+	return 0, ErrNotFound
+}
+
+// searchRangeBackward searches backward from lineNum to start.
+// Only the lines in the range are searched.
+func (m *Document) searchRangeBackward(ctx context.Context, searcher Searcher, lineNum int, start int) (int, error) {
+	startChunk, sn := chunkLineNum(lineNum)
+	minChunk, minN := chunkLineNum(start)
+	defer m.startProgress(ctx, startChunk, minChunk, false)()
+	for cn := startChunk; cn >= minChunk; cn-- {
+		en := 0
+		if cn == minChunk {
+			en = minN
+		}
+		n, err := m.searchChunkRange(ctx, searcher, cn, sn, en)
+		if err == nil {
+			return cn*ChunkSize + n, nil
+		}
+		if errors.Is(err, ErrCancel) {
+			return 0, err
+		}
+		sn = ChunkSize - 1
+	}
+	return 0, ErrNotFound
+}
+
+// searchChunkRange searches the lines from sn to en (inclusive) of the chunk.
+// It searches backward if en is less than sn.
+func (m *Document) searchChunkRange(ctx context.Context, searcher Searcher, chunkNum int, sn int, en int) (int, error) {
+	progressFromContext(ctx).update(chunkNum)
+	if m.skipChunk(searcher, chunkNum) {
+		return 0, ErrNotFound
+	}
+	if !m.store.isLoadedChunk(chunkNum, m.seekable) && !m.storageSearch(searcher, chunkNum) {
+		return 0, ErrNotFound
+	}
+	step := 1
+	if en < sn {
+		step = -1
+	}
+	for n := sn; n != en+step; n += step {
+		buf, err := m.store.GetChunkLine(chunkNum, n)
+		if err != nil {
+			return 0, ErrNotFound
+		}
+		if searcher.Match(buf) != m.nonMatch {
+			return n, nil
+		}
+		select {
+		case <-ctx.Done():
+			return 0, ErrCancel
+		default:
+		}
+	}
+	return 0, ErrNotFound
+}
+
+// wrapSearchLineRange searches from the beginning of the range (from the end if backward).
+func (m *Document) wrapSearchLineRange(ctx context.Context, searcher Searcher, forward bool, start int, end int) (int, error) {
+	if end < 0 {
+		return m.wrapSearchLine(ctx, searcher, forward)
+	}
+	if forward {
+		return m.searchLineRange(ctx, searcher, forward, start, start, end)
+	}
+	return m.searchLineRange(ctx, searcher, forward, end-1, start, end)
+}
+
+// sectionFilterWriter searches and writes the whole sections containing the matching lines to filterDoc.
+func (m *Document) sectionFilterWriter(ctx context.Context, searcher Searcher, startLN int, filterDoc *filterDocument) {
+	defer filterDoc.w.Close()
+	startChunk, _ := chunkLineNum(startLN)
+	defer m.startProgress(ctx, startChunk, 0, true)()
+	for originLN, renderLN := startLN, startLN; ; {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+		lineNum, err := m.searchLine(ctx, searcher, true, originLN)
+		if err != nil {
+			// Not found
+			return
+		}
+		start, end := m.sectionRange(ctx, lineNum)
+		// The lines before originLN have already been written.
+		for lN := max(start, originLN); lN < end; lN++ {
+			if chunkNum, _ := chunkLineNum(lN); !m.store.isLoadedChunk(chunkNum, m.seekable) {
+				m.requestLoadSync(chunkNum)
+			}
+			line, err := m.Line(lN)
+			if err != nil {
+				log.Println(err)
+				return
+			}
+			filterDoc.lineNumMap.Store(renderLN, lN)
+			writeLine(filterDoc.w, line)
+			renderLN++
+		}
+		originLN = end
+	}
+}
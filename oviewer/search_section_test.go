@@ -0,0 +1,145 @@
+package oviewer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// sectionRootHelper returns a root with the sections of "#" lines.
+func sectionRootHelper(t *testing.T) *Root {
+	t.Helper()
+	tcellNewScreen = fakeScreen
+	t.Cleanup(func() {
+		tcellNewScreen = tcell.NewScreen
+	})
+	root, err := NewRoot(bytes.NewBufferString("intro\n# a\nerror a1\nok\n# b\nok\nerror b1\nerror b2\n# c\nok\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	root.Doc.WaitEOF()
+	root.prepareScreen()
+	root.Doc.setSectionDelimiter("^#")
+	return root
+}
+
+func TestDocument_sectionRange(t *testing.T) {
+	root := sectionRootHelper(t)
+	tests := []struct {
+		name      string
+		lN        int
+		wantStart int
+		wantEnd   int
+	}{
+		{name: "beforeFirst", lN: 0, wantStart: 0, wantEnd: 1},
+		{name: "delimiter", lN: 1, wantStart: 1, wantEnd: 4},
+		{name: "middle", lN: 6, wantStart: 4, wantEnd: 8},
+		{name: "last", lN: 9, wantStart: 8, wantEnd: 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := root.Doc.sectionRange(context.Background(), tt.lN)
+			if start != tt.wantStart || end != tt.wantEnd {
+				t.Errorf("sectionRange() = %d, %d, want %d, %d", start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+// countSearcher counts the lines matched by the Searcher.
+type countSearcher struct {
+	Searcher
+	count *atomic.Int32
+}
+
+func (s countSearcher) Match(target []byte) bool {
+	s.count.Add(1)
+	return s.Searcher.Match(target)
+}
+
+func TestDocument_searchLineRange(t *testing.T) {
+	m := docHelper(t, "a\nb\nc\nd\n"+strings.Repeat("x\n", ChunkSize*2)+"error\n")
+	searcher := countSearcher{Searcher: NewSearcher("error", nil, false, false), count: &atomic.Int32{}}
+	ctx := context.Background()
+	// The lines outside the range are not searched.
+	if _, err := m.searchLineRange(ctx, searcher, true, 0, 1, 3); !errors.Is(err, ErrNotFound) {
+		t.Errorf("searchLineRange() error = %v, want %v", err, ErrNotFound)
+	}
+	if got := searcher.count.Load(); got != 2 {
+		t.Errorf("searched %d lines, want 2", got)
+	}
+	searcher.count.Store(0)
+	if _, err := m.searchLineRange(ctx, searcher, false, ChunkSize*2, 1, 3); !errors.Is(err, ErrNotFound) {
+		t.Errorf("searchLineRange() error = %v, want %v", err, ErrNotFound)
+	}
+	if got := searcher.count.Load(); got != 2 {
+		t.Errorf("searched %d lines, want 2", got)
+	}
+	if got, err := m.searchLineRange(ctx, searcher, true, 0, 0, -1); err != nil || got != ChunkSize*2+4 {
+		t.Errorf("searchLineRange() = %d, %v, want %d", got, err, ChunkSize*2+4)
+	}
+}
+
+func TestRoot_sectionSearch(t *testing.T) {
+	root := sectionRootHelper(t)
+	ctx := context.Background()
+	root.Config.SectionSearch = true
+	searcher := NewSearcher("error", nil, false, false)
+
+	// The current section is "# b".
+	root.Doc.moveLine(4)
+	if !root.searchMove(ctx, true, 4, searcher) {
+		t.Fatal("searchMove() = false, want true")
+	}
+	if got := searchMoveLine(t, root); got != 6 {
+		t.Errorf("searchMove() = %v, want 6", got)
+	}
+	if !root.searchMove(ctx, true, 7, searcher) {
+		t.Fatal("searchMove() = false, want true")
+	}
+	if got := searchMoveLine(t, root); got != 7 {
+		t.Errorf("searchMove() = %v, want 7", got)
+	}
+	// The next match is in the next section.
+	if root.searchMove(ctx, true, 8, searcher) {
+		t.Errorf("searchMove() = true, want false")
+	}
+	// "error a1" is in the previous section.
+	if root.searchMove(ctx, false, 5, searcher) {
+		t.Errorf("searchMove() = true, want false")
+	}
+
+	// Wrap-around in the section.
+	root.Config.WrapSearch = true
+	if !root.searchMove(ctx, true, 8, searcher) {
+		t.Fatal("searchMove() = false, want true")
+	}
+	if got := searchMoveLine(t, root); got != 6 {
+		t.Errorf("searchMove() = %v, want 6", got)
+	}
+}
+
+func TestRoot_sectionFilter(t *testing.T) {
+	root := sectionRootHelper(t)
+	root.Config.SectionSearch = true
+	root.filterDocument(context.Background(), NewSearcher("error b", nil, false, false))
+	filterDoc := root.DocList[len(root.DocList)-1]
+	filterDoc.WaitEOF()
+	want := []string{"# b", "ok", "error b1", "error b2"}
+	if got := filterDoc.BufEndNum(); got != len(want) {
+		t.Fatalf("BufEndNum() = %d, want %d", got, len(want))
+	}
+	for i, w := range want {
+		if got, _ := filterDoc.LineStr(i); got != w {
+			t.Errorf("LineStr(%d) = %q, want %q", i, got, w)
+		}
+	}
+	if got, _ := filterDoc.lineNumMap.LoadForward(2); got != 6 {
+		t.Errorf("lineNumMap(2) = %d, want 6", got)
+	}
+}
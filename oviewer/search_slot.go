@@ -0,0 +1,191 @@
+package oviewer
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// searchSlotNum is the number of search slots.
+const searchSlotNum = 9
+
+// slotSearcher is a searcher that matches any of the search slots.
+type slotSearcher struct {
+	searchers []Searcher
+}
+
+// slotSearcher Match returns true if any of the slots matches.
+func (s slotSearcher) Match(target []byte) bool {
+	for _, searcher := range s.searchers {
+		if searcher.Match(target) {
+			return true
+		}
+	}
+	return false
+}
+
+// slotSearcher MatchString returns true if any of the slots matches.
+func (s slotSearcher) MatchString(target string) bool {
+	for _, searcher := range s.searchers {
+		if searcher.MatchString(target) {
+			return true
+		}
+	}
+	return false
+}
+
+// slotSearcher FindAll returns the merged positions of all the slots.
+func (s slotSearcher) FindAll(target string) [][]int {
+	var indexes [][]int
+	for _, searcher := range s.searchers {
+		indexes = append(indexes, searcher.FindAll(target)...)
+	}
+	return mergeIndexes(indexes)
+}
+
+// slotSearcher String returns the search words of the slots.
+func (s slotSearcher) String() string {
+	words := make([]string, 0, len(s.searchers))
+	for _, searcher := range s.searchers {
+		words = append(words, searcher.String())
+	}
+	return strings.Join(words, " | ")
+}
+
+// parseSearchSlot parses the input of the search slot.
+// "n:word" sets the word to slot n (1-9), and "n:" clears slot n.
+// Any other input (including words starting with digits such as "404 error")
+// is set to the first empty slot.
+// It returns the slot number (1-based) and the word.
+func parseSearchSlot(input string, slots [searchSlotNum]Searcher) (int, string, error) {
+	input = strings.TrimLeft(input, " ")
+	if len(input) >= 2 && input[0] >= '1' && input[0] <= '0'+searchSlotNum && input[1] == ':' {
+		return int(input[0] - '0'), input[2:], nil
+	}
+	for i, searcher := range slots {
+		if searcher == nil {
+			return i + 1, input, nil
+		}
+	}
+	return 0, "", ErrNoEmptySearchSlot
+}
+
+// setSearchSlot sets the search word to the search slot.
+func (root *Root) setSearchSlot(input string) {
+	num, word, err := parseSearchSlot(input, root.searchSlots)
+	if err != nil {
+		root.setMessageLogf("search slot: %v", err)
+		return
+	}
+	if word == "" {
+		root.searchSlots[num-1] = nil
+		root.setMessagef("clear search slot %d", num)
+		return
+	}
+	root.searchSlots[num-1] = root.newSearcher(word, root.Config.CaseSensitive)
+	root.setMessagef("search slot %d:%s", num, word)
+}
+
+// slotSearcher returns the searcher of the slot (1-based), or the searcher of all slots if num is 0.
+// It returns nil if the slot is empty.
+// The searcher is always a slotSearcher, so that the move to the match does not change the match count of the search.
+func (root *Root) slotSearcher(num int) Searcher {
+	slots := root.searchSlots[:]
+	if num > 0 {
+		slots = slots[num-1 : num]
+	}
+	var searchers []Searcher
+	for _, searcher := range slots {
+		if searcher != nil {
+			searchers = append(searchers, searcher)
+		}
+	}
+	if len(searchers) == 0 {
+		return nil
+	}
+	return slotSearcher{searchers: searchers}
+}
+
+// slotSearchMove moves to the next or previous match of the slot (all slots if num is 0).
+func (root *Root) slotSearchMove(ctx context.Context, num int, forward bool) {
+	searcher := root.slotSearcher(num)
+	if searcher == nil {
+		if num > 0 {
+			root.setMessagef("search slot %d is empty", num)
+		} else {
+			root.setMessage("no search slot")
+		}
+		return
+	}
+	next := 1
+	if !forward {
+		next = -1
+	}
+	root.searchMove(ctx, forward, root.startSearchLN()+next, searcher)
+}
+
+// slotAction returns the action name for the search slot.
+func slotAction(action string, num int) string {
+	return action + "_" + strconv.Itoa(num)
+}
+
+// slotHandlers returns the handlers to move to the match of each search slot.
+func (root *Root) slotHandlers() map[string]func(context.Context) {
+	handlers := make(map[string]func(context.Context), searchSlotNum*2)
+	for num := 1; num <= searchSlotNum; num++ {
+		handlers[slotAction(actionNextSlot, num)] = func(ctx context.Context) {
+			root.slotSearchMove(ctx, num, true)
+		}
+		handlers[slotAction(actionPrevSlot, num)] = func(ctx context.Context) {
+			root.slotSearchMove(ctx, num, false)
+		}
+	}
+	return handlers
+}
+
+// nextSlotSearch moves to the next match of any search slot.
+func (root *Root) nextSlotSearch(ctx context.Context) {
+	root.slotSearchMove(ctx, 0, true)
+}
+
+// prevSlotSearch moves to the previous match of any search slot.
+func (root *Root) prevSlotSearch(ctx context.Context) {
+	root.slotSearchMove(ctx, 0, false)
+}
+
+// searchSlotHighlight applies the style of each search slot.
+// The style of the smaller slot number takes precedence.
+func (root *Root) searchSlotHighlight(lineC LineC) {
+	styles := root.Doc.Style.SearchSlotHighlight
+	if len(styles) == 0 {
+		return
+	}
+	for i := len(root.searchSlots) - 1; i >= 0; i-- {
+		searcher := root.searchSlots[i]
+		if searcher == nil {
+			continue
+		}
+		for _, idx := range searcher.FindAll(lineC.str) {
+			RangeStyle(lineC.lc, lineC.pos.x(idx[0]), lineC.pos.x(idx[1]), styles[i%len(styles)])
+		}
+	}
+}
+
+// slotStatus returns the search slots to display in the status line.
+func (root *Root) slotStatus() contents {
+	var status contents
+	styles := root.Doc.Style.SearchSlotHighlight
+	for i, searcher := range root.searchSlots {
+		if searcher == nil {
+			continue
+		}
+		c := StrToContents(fmt.Sprintf("%d:%s", i+1, searcher.String()), -1)
+		if len(styles) > 0 {
+			RangeStyle(c, 0, len(c), styles[i%len(styles)])
+		}
+		status = append(status, c...)
+		status = append(status, StrToContents(" ", -1)...)
+	}
+	return status
+}
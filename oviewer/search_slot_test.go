@@ -0,0 +1,155 @@
+package oviewer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func Test_parseSearchSlot(t *testing.T) {
+	full := [searchSlotNum]Searcher{}
+	for i := range full {
+		full[i] = NewSearcher("a", nil, false, false)
+	}
+	second := [searchSlotNum]Searcher{NewSearcher("a", nil, false, false)}
+	tests := []struct {
+		name     string
+		input    string
+		slots    [searchSlotNum]Searcher
+		wantNum  int
+		wantWord string
+		wantErr  error
+	}{
+		{name: "slot", input: "2:error", wantNum: 2, wantWord: "error"},
+		{name: "slotWithSpace", input: "3:connection refused", wantNum: 3, wantWord: "connection refused"},
+		{name: "clear", input: "4:", wantNum: 4, wantWord: ""},
+		{name: "firstEmpty", input: "timeout", slots: second, wantNum: 2, wantWord: "timeout"},
+		{name: "digitsWord", input: "404 error", wantNum: 1, wantWord: "404 error"},
+		{name: "number", input: "3", wantNum: 1, wantWord: "3"},
+		{name: "time", input: "10:30", wantNum: 1, wantWord: "10:30"},
+		{name: "zero", input: "0:error", wantNum: 1, wantWord: "0:error"},
+		{name: "slotDigits", input: "5:404", wantNum: 5, wantWord: "404"},
+		{name: "noEmpty", input: "error", slots: full, wantErr: ErrNoEmptySearchSlot},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			num, word, err := parseSearchSlot(tt.input, tt.slots)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("parseSearchSlot() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if num != tt.wantNum || word != tt.wantWord {
+				t.Errorf("parseSearchSlot() = %v, %q, want %v, %q", num, word, tt.wantNum, tt.wantWord)
+			}
+		})
+	}
+}
+
+func Test_slotSearcher(t *testing.T) {
+	s := slotSearcher{searchers: []Searcher{
+		NewSearcher("error", nil, false, false),
+		NewSearcher("or 4", nil, false, false),
+		NewSearcher("404", nil, false, false),
+	}}
+	if !s.Match([]byte("status 404")) || !s.MatchString("ERROR") {
+		t.Errorf("Match() = false, want true")
+	}
+	if s.MatchString("ok") {
+		t.Errorf("MatchString() = true, want false")
+	}
+	if got, want := s.FindAll("error 404"), [][]int{{0, 9}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FindAll() = %v, want %v", got, want)
+	}
+	if got, want := s.String(), "error | or 4 | 404"; got != want {
+		t.Errorf("String() = %v, want %v", got, want)
+	}
+}
+
+func TestRoot_searchSlot(t *testing.T) {
+	tcellNewScreen = fakeScreen
+	defer func() {
+		tcellNewScreen = tcell.NewScreen
+	}()
+	root, err := NewRoot(bytes.NewBufferString("start\nuser=alice\nstatus=500\nuser=bob\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	root.Doc.WaitEOF()
+	root.prepareScreen()
+	ctx := context.Background()
+	root.everyUpdate(ctx)
+
+	root.setSearchSlot("user")
+	root.setSearchSlot("3:status")
+	if root.searchSlots[0] == nil || root.searchSlots[2] == nil {
+		t.Fatalf("setSearchSlot() = %v", root.searchSlots)
+	}
+	if got, want := root.slotStatus().String(), "1:user 3:status "; got != want {
+		t.Errorf("slotStatus() = %q, want %q", got, want)
+	}
+
+	// Slot 1 is highlighted with the first style.
+	lineC := root.Doc.getLineC(1)
+	root.searchSlotHighlight(lineC)
+	want := applyStyle(tcell.StyleDefault, root.Doc.Style.SearchSlotHighlight[0])
+	if got := lineC.lc[0].style; got != want {
+		t.Errorf("style = %v, want %v", got, want)
+	}
+
+	if _, ok := root.handlers()[slotAction(actionNextSlot, 3)]; !ok {
+		t.Errorf("handler of %s is not found", slotAction(actionNextSlot, 3))
+	}
+
+	// Search from the line after the top.
+	root.slotSearchMove(ctx, 3, true)
+	if got := searchMoveLine(t, root); got != 2 {
+		t.Errorf("slotSearchMove() = %v, want %v", got, 2)
+	}
+	root.slotSearchMove(ctx, 0, true)
+	if got := searchMoveLine(t, root); got != 1 {
+		t.Errorf("slotSearchMove() = %v, want %v", got, 1)
+	}
+	root.slotSearchMove(ctx, 5, true)
+	if root.message != "search slot 5 is empty" {
+		t.Errorf("slotSearchMove() message = %q", root.message)
+	}
+
+	// Moving to the match of the slot does not change the match count of the search.
+	searcher := root.setSearcher("user", false)
+	root.searchGo(ctx, 3, searcher)
+	key := root.Doc.matchCount.key
+	root.slotSearchMove(ctx, 3, false)
+	root.searchGo(ctx, searchMoveLine(t, root), root.slotSearcher(3))
+	if root.Doc.lastSearchLN != 2 {
+		t.Errorf("lastSearchLN = %v, want %v", root.Doc.lastSearchLN, 2)
+	}
+	if root.Doc.matchLN != 3 || root.Doc.matchCount.key != key {
+		t.Errorf("match count = %v, %q, want %v, %q", root.Doc.matchLN, root.Doc.matchCount.key, 3, key)
+	}
+
+	root.setSearchSlot("3:")
+	if root.searchSlots[2] != nil {
+		t.Errorf("setSearchSlot() did not clear slot 3")
+	}
+}
+
+// searchMoveLine returns the line number of the pending eventSearchMove.
+func searchMoveLine(t *testing.T, root *Root) int {
+	t.Helper()
+	for root.Screen.HasPendingEvent() {
+		if ev, ok := root.Screen.PollEvent().(*eventSearchMove); ok {
+			return ev.ln
+		}
+	}
+	t.Fatal("no search move event")
+	return -1
+}
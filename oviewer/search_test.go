@@ -1,6 +1,7 @@
 package oviewer
 
 import (
+	"bytes"
 	"context"
 	"path/filepath"
 	"reflect"
@@ -530,6 +531,100 @@ func TestRoot_setSearch(t *testing.T) {
 				word: "Test",
 			},
 		},
+		{
+			name: "testExprOff",
+			fields: fields{
+				input: &Input{},
+			},
+			args: args{
+				word:          "404 NOT FOUND",
+				caseSensitive: false,
+			},
+			want: searchWord{
+				word: "404 not found",
+			},
+		},
+		{
+			name: "testExpr",
+			config: Config{
+				ExprSearch: true,
+			},
+			fields: fields{
+				input: &Input{},
+			},
+			args: args{
+				word:          "test AND NOT Test2",
+				caseSensitive: false,
+			},
+			want: searchExpr{
+				word: "test AND NOT Test2",
+				root: &exprNode{
+					op: exprAnd,
+					children: []*exprNode{
+						{op: exprTerm, searcher: searchWord{word: "test"}},
+						{op: exprNot, children: []*exprNode{
+							{op: exprTerm, searcher: searchWord{word: "test2"}},
+						}},
+					},
+				},
+				positives: []Searcher{searchWord{word: "test"}},
+			},
+		},
+		{
+			name: "testExprInvalid",
+			config: Config{
+				ExprSearch: true,
+			},
+			fields: fields{
+				input: &Input{},
+			},
+			args: args{
+				word:          "test AND",
+				caseSensitive: false,
+			},
+			want: searchWord{
+				word: "test and",
+			},
+		},
+		{
+			name: "testFuzzy",
+			config: Config{
+				FuzzySearch:        true,
+				SmartCaseSensitive: true,
+			},
+			fields: fields{
+				input: &Input{},
+			},
+			args: args{
+				word:          "Connection",
+				caseSensitive: false,
+			},
+			want: fuzzyWord{
+				word:          "Connection",
+				pattern:       []rune("Connection"),
+				caseSensitive: true,
+				maxTypos:      2,
+			},
+		},
+		{
+			name: "testFuzzyPCREPrefix",
+			config: Config{
+				FuzzySearch: true,
+			},
+			fields: fields{
+				input: &Input{},
+			},
+			args: args{
+				word:          "(?pcre)abc",
+				caseSensitive: true,
+			},
+			want: fuzzyWord{
+				word:          "(?pcre)abc",
+				pattern:       []rune("(?pcre)abc"),
+				caseSensitive: true,
+				maxTypos:      2,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1151,3 +1246,53 @@ func Test_cancelKeys(t *testing.T) {
 		})
 	}
 }
+
+func TestRoot_wrapSearch(t *testing.T) {
+	tcellNewScreen = fakeScreen
+	defer func() {
+		tcellNewScreen = tcell.NewScreen
+	}()
+	root, err := NewRoot(bytes.NewBufferString("error 1\nok\nerror 2\nok\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	root.Doc.WaitEOF()
+	root.prepareScreen()
+	ctx := context.Background()
+	searcher := NewSearcher("error", nil, false, false)
+
+	// Not found without wrap-around.
+	if root.searchMove(ctx, true, 3, searcher) {
+		t.Errorf("searchMove() = true, want false")
+	}
+
+	root.Config.WrapSearch = true
+	if !root.searchMove(ctx, true, 3, searcher) {
+		t.Fatal("searchMove() = false, want true")
+	}
+	if got := searchMoveLine(t, root); got != 0 {
+		t.Errorf("searchMove() = %v, want 0", got)
+	}
+	if root.message != "search wrapped:error" {
+		t.Errorf("message = %q, want search wrapped", root.message)
+	}
+
+	// Backward search continues from the end.
+	if !root.searchMove(ctx, false, 1, NewSearcher("error 2", nil, false, false)) {
+		t.Fatal("searchMove() = false, want true")
+	}
+	if got := searchMoveLine(t, root); got != 2 {
+		t.Errorf("searchMove() = %v, want 2", got)
+	}
+
+	// Not wrapped.
+	if !root.searchMove(ctx, true, 1, searcher) {
+		t.Fatal("searchMove() = false, want true")
+	}
+	if got := searchMoveLine(t, root); got != 2 {
+		t.Errorf("searchMove() = %v, want 2", got)
+	}
+	if root.message != "search:error" {
+		t.Errorf("message = %q, want search:error", root.message)
+	}
+}
@@ -132,11 +132,11 @@ func (root *Root) rightStatus() contents {
 	if !root.Doc.BufEOF() {
 		next = "..."
 	}
-	str := fmt.Sprintf("(%d/%d%s)", root.Doc.firstLine()+root.Doc.topLN+1, root.Doc.BufEndNum(), next)
+	str := root.matchStatus() + fmt.Sprintf("(%d/%d%s)", root.Doc.firstLine()+root.Doc.topLN+1, root.Doc.BufEndNum(), next)
 	if atomic.LoadInt32(&root.Doc.tmpFollow) == 1 {
 		str = fmt.Sprintf("(?/%d%s)", root.Doc.storeEndNum(), next)
 	}
 	contents := StrToContents(str, -1)
 	RangeStyle(contents, 0, len(contents), root.Doc.Style.RightStatus)
-	return contents
+	return append(root.slotStatus(), contents...)
 }